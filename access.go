@@ -0,0 +1,46 @@
+package envy
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// AccessHook is called with every key read from an Env through Get,
+// MustGet, or Lookup, whether it was found, and the caller that read
+// it, as "file:line".
+type AccessHook func(key string, found bool, caller string)
+
+// OnAccess registers a hook called every time a key is read from
+// the Env, so the code paths reading secrets can be audited. Hooks
+// are called synchronously, and must be fast.
+func (e *Env) OnAccess(fn AccessHook) {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.accessHooks = append(e.accessHooks, fn)
+}
+
+// OnAccess registers a hook called every time a key
+// is read through envy.
+func OnAccess(fn AccessHook) {
+	env.OnAccess(fn)
+}
+
+const pkgPrefix = "github.com/gobuffalo/envy."
+
+// caller returns the "file:line" of the first caller outside of envy.
+func caller() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		inside := strings.HasPrefix(f.Function, pkgPrefix) && !strings.HasSuffix(f.File, "_test.go")
+		if !inside {
+			return fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package envy
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_OnAccess(t *testing.T) {
+	r := require.New(t)
+
+	type access struct {
+		key    string
+		found  bool
+		caller string
+	}
+	var got []access
+
+	e := FromMap(map[string]string{"API_TOKEN": "hunter2"})
+	e.OnAccess(func(key string, found bool, caller string) {
+		got = append(got, access{key, found, caller})
+	})
+
+	e.Get("API_TOKEN", "")
+	e.MustGet("NOPE")
+	_, ok := e.Lookup("API_TOKEN")
+	r.True(ok)
+	e.Map()
+
+	r.Len(got, 3)
+	r.Equal("API_TOKEN", got[0].key)
+	r.True(got[0].found)
+	r.Equal("NOPE", got[1].key)
+	r.False(got[1].found)
+	r.True(got[2].found)
+
+	for _, a := range got {
+		r.True(strings.HasPrefix(filepath.Base(a.caller), "access_test.go:"), a.caller)
+	}
+}
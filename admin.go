@@ -0,0 +1,66 @@
+package envy
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// AdminHandler returns an http.Handler for operators to manage the
+// Env of a running service, serving:
+//
+//	POST /reload   Reload the Env, then respond like GET /sources
+//	GET  /dump     the DebugDump of the Env, as plain text
+//	GET  /sources  the Statuses of the Env, as JSON
+//
+// Other methods, than HEAD for the GET ones, are answered with a
+// 405 Method Not Allowed.
+//
+// It isn't protected in any way, and should be mounted, with
+// http.StripPrefix, behind authentication:
+//
+//	mux.Handle("/admin/envy/", http.StripPrefix("/admin/envy", auth(e.AdminHandler())))
+func (e *Env) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/reload", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethods(w, r, http.MethodPost) {
+			return
+		}
+		e.Reload()
+		e.serveStatuses(w)
+	})
+	mux.HandleFunc("/dump", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethods(w, r, http.MethodGet, http.MethodHead) {
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		e.DebugDump(w)
+	})
+	mux.HandleFunc("/sources", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethods(w, r, http.MethodGet, http.MethodHead) {
+			return
+		}
+		e.serveStatuses(w)
+	})
+	return mux
+}
+
+// allowMethods reports whether the method of r is one of methods,
+// responding with a 405 Method Not Allowed when it isn't.
+func allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, m := range methods {
+		if r.Method == m {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	return false
+}
+
+func (e *Env) serveStatuses(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(e.Statuses())
+}
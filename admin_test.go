@@ -0,0 +1,69 @@
+package envy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_AdminHandler(t *testing.T) {
+	r := require.New(t)
+
+	e := New()
+	e.Set("API_TOKEN", "abc")
+	h := e.AdminHandler()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/reload", nil))
+	r.Equal(http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/reload", nil))
+	r.Equal(http.StatusOK, w.Code)
+	var sts []SourceStatus
+	r.NoError(json.Unmarshal(w.Body.Bytes(), &sts))
+	r.Equal("os", sts[0].Source)
+
+	// reloading from the OS drops the value only set on the Env
+	_, ok := e.Lookup("API_TOKEN")
+	r.False(ok)
+
+	e.Set("API_TOKEN", "abc")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/dump", nil))
+	r.Equal("text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	r.Contains(w.Body.String(), `API_TOKEN`)
+	r.Contains(w.Body.String(), Redacted)
+	r.NotContains(w.Body.String(), `"abc"`)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/sources", nil))
+	r.NoError(json.Unmarshal(w.Body.Bytes(), &sts))
+	r.Len(sts, 1)
+
+	for _, path := range []string{"/dump", "/sources"} {
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("HEAD", path, nil))
+		r.Equal(http.StatusOK, w.Code, path)
+
+		for _, m := range []string{"POST", "PUT", "DELETE"} {
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(m, path, nil))
+			r.Equal(http.StatusMethodNotAllowed, w.Code, m+" "+path)
+			r.Equal("GET, HEAD", w.Header().Get("Allow"))
+		}
+	}
+}
+
+func Test_NoDebugVars(t *testing.T) {
+	r := require.New(t)
+
+	// envy mustn't import expvar, which serves /debug/vars on the
+	// default mux, see the envyexpvar package
+	w := httptest.NewRecorder()
+	http.DefaultServeMux.ServeHTTP(w, httptest.NewRequest("GET", "/debug/vars", nil))
+	r.Equal(http.StatusNotFound, w.Code)
+}
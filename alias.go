@@ -0,0 +1,45 @@
+package envy
+
+// Alias makes key, and each of the aliases, resolve to the same value
+// when they're read, with Get, MustGet, Lookup, and the functions
+// built on them, smoothing over the different names providers and
+// SDKs use for the same setting. When more than one of them is set,
+// key takes precedence, followed by the aliases in order:
+//
+//	e.Alias("DATABASE_URL", "DB_URL", "POSTGRES_URL")
+//
+// Values are still set, and mapped, under their own names. Aliasing a
+// name again replaces what it was aliased to.
+func (e *Env) Alias(key string, aliases ...string) {
+	names := append([]string{key}, aliases...)
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	if e.aliases == nil {
+		e.aliases = map[string][]string{}
+	}
+	for _, n := range names {
+		e.aliases[n] = names
+	}
+}
+
+// Alias makes key, and each of the aliases,
+// resolve to the same value in envy.
+func Alias(key string, aliases ...string) {
+	env.Alias(key, aliases...)
+}
+
+// resolve returns the value of key, or of the first of the names it's
+// aliased to that is set, applying the EmptyPolicy of the Env. It
+// must be called with e.moot held.
+func (e *Env) resolve(key string) (string, bool) {
+	names, ok := e.aliases[key]
+	if !ok {
+		names = []string{key}
+	}
+	for _, n := range names {
+		if v, ok := e.get(n); ok && (v != "" || e.empty == EmptyIsValue) {
+			return v, true
+		}
+	}
+	return "", false
+}
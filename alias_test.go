@@ -0,0 +1,36 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Alias(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"DB_URL": "postgres://b"})
+	e.Alias("DATABASE_URL", "DB_URL", "POSTGRES_URL")
+
+	r.Equal("postgres://b", e.Get("DATABASE_URL", ""))
+	r.Equal("postgres://b", e.Get("POSTGRES_URL", ""))
+
+	e.Set("DATABASE_URL", "postgres://a")
+	r.Equal("postgres://a", e.Get("DB_URL", ""))
+	v, err := e.MustGet("POSTGRES_URL")
+	r.NoError(err)
+	r.Equal("postgres://a", v)
+
+	r.Equal(map[string]string{
+		"DATABASE_URL": "postgres://a",
+		"DB_URL":       "postgres://b",
+	}, e.Map())
+
+	c := e.Clone()
+	c.Unset("DATABASE_URL")
+	r.Equal("postgres://b", c.Get("POSTGRES_URL", ""))
+	r.Equal("postgres://a", e.Get("POSTGRES_URL", ""))
+
+	_, ok := FromMap(nil).Lookup("DB_URL")
+	r.False(ok)
+}
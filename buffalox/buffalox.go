@@ -0,0 +1,72 @@
+/*
+Package buffalox makes envy available to the handlers of Buffalo apps,
+through an Env scoped to the request, so per-request, or per-tenant,
+configuration overrides are read with the same Get API.
+
+It's its own module, so envy itself doesn't depend on Buffalo.
+*/
+package buffalox
+
+import (
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/envy"
+)
+
+// ContextKey is the key the Env of the request is set with.
+const ContextKey = "envy"
+
+// Middleware sets the Env of every request to base, or the default
+// Env when base is nil, overlaid with the values overrides returns for
+// the request, see envy.Overlay. overrides may be nil. It's set on the
+// buffalo.Context, see Env, and on the context of the request, see
+// envy.FromContext.
+//
+//	app.Use(buffalox.Middleware(nil, func(c buffalo.Context) map[string]string {
+//		return tenants[c.Request().Host]
+//	}))
+func Middleware(base *envy.Env, overrides func(c buffalo.Context) map[string]string) buffalo.MiddlewareFunc {
+	if base == nil {
+		base = envy.Default()
+	}
+	return func(next buffalo.Handler) buffalo.Handler {
+		return func(c buffalo.Context) error {
+			e := base
+			if overrides != nil {
+				if o := overrides(c); len(o) > 0 {
+					e = base.Overlay(o)
+				}
+			}
+			c.Set(ContextKey, e)
+			req := c.Request()
+			return next(requestContext{
+				Context: c,
+				req:     req.WithContext(envy.NewContext(req.Context(), e)),
+			})
+		}
+	}
+}
+
+// requestContext is the buffalo.Context handed to the handlers after
+// Middleware, whose request is a copy of the original one carrying the
+// Env in its context, so code only handed the context of the request
+// reads it through envy.FromContext, while the original request, which
+// others may hold, is left untouched.
+type requestContext struct {
+	buffalo.Context
+	req *http.Request
+}
+
+func (c requestContext) Request() *http.Request {
+	return c.req
+}
+
+// Env returns the Env of the request, set by Middleware, falling
+// back to the Env carried by its context, see envy.FromContext.
+func Env(c buffalo.Context) *envy.Env {
+	if e, ok := c.Value(ContextKey).(*envy.Env); ok {
+		return e
+	}
+	return envy.FromContext(c)
+}
@@ -0,0 +1,69 @@
+package buffalox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/envy"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Middleware(t *testing.T) {
+	r := require.New(t)
+
+	base := envy.FromMap(map[string]string{"TENANT": "default"})
+	app := buffalo.New(buffalo.Options{})
+	app.Use(Middleware(base, func(c buffalo.Context) map[string]string {
+		if c.Request().Host == "acme.example.com" {
+			return map[string]string{"TENANT": "acme"}
+		}
+		return nil
+	}))
+	app.GET("/tenant", func(c buffalo.Context) error {
+		_, err := c.Response().Write([]byte(Env(c).Get("TENANT", "")))
+		return err
+	})
+
+	for host, want := range map[string]string{
+		"acme.example.com":  "acme",
+		"other.example.com": "default",
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/tenant", nil)
+		req.Host = host
+		app.ServeHTTP(w, req)
+		r.Equal(want, w.Body.String())
+	}
+}
+
+func Test_Middleware_RequestContext(t *testing.T) {
+	r := require.New(t)
+
+	base := envy.FromMap(map[string]string{"TENANT": "default"})
+	app := buffalo.New(buffalo.Options{})
+	var outer *http.Request
+	app.Use(func(next buffalo.Handler) buffalo.Handler {
+		return func(c buffalo.Context) error {
+			outer = c.Request()
+			return next(c)
+		}
+	})
+	app.Use(Middleware(base, func(c buffalo.Context) map[string]string {
+		return map[string]string{"TENANT": "acme"}
+	}))
+	app.GET("/tenant", func(c buffalo.Context) error {
+		e := envy.FromContext(c.Request().Context())
+		_, err := c.Response().Write([]byte(e.Get("TENANT", "")))
+		return err
+	})
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest("GET", "/tenant", nil))
+	r.Equal("acme", w.Body.String())
+
+	// the request the other middlewares hold is left untouched
+	r.NotNil(outer)
+	r.Equal(envy.Default(), envy.FromContext(outer.Context()))
+}
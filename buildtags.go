@@ -0,0 +1,61 @@
+package envy
+
+import "strings"
+
+// BuildTags returns the build tags that are active for the go
+// commands run by the process: those of the -tags flag in GOFLAGS,
+// followed by those of BUILD_TAGS, a comma or space separated list
+// applications use for the tags they build with, and by those given
+// to WithBuildTags. Repeated tags are only returned once.
+func (e *Env) BuildTags() []string {
+	var tags []string
+	for _, f := range e.GoFlags() {
+		if goFlagName(f) != "tags" {
+			continue
+		}
+		if i := strings.IndexByte(f, '='); i != -1 {
+			tags = append(tags, splitTags(f[i+1:])...)
+		}
+	}
+	tags = append(tags, splitTags(e.Get("BUILD_TAGS", ""))...)
+
+	e.moot.RLock()
+	tags = append(tags, e.buildTags...)
+	e.moot.RUnlock()
+	return dedupTags(tags)
+}
+
+// BuildTags returns the active build tags, see Env.BuildTags.
+func BuildTags() []string {
+	return env.BuildTags()
+}
+
+// WithBuildTags adds tags to the build tags of the Env, see BuildTags.
+func WithBuildTags(tags ...string) Option {
+	return func(e *Env) {
+		for _, t := range tags {
+			e.buildTags = append(e.buildTags, splitTags(t)...)
+		}
+	}
+}
+
+// splitTags splits a list of tags on commas and spaces, accepting
+// both the current and the older form of the -tags flag.
+func splitTags(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+func dedupTags(tags []string) []string {
+	seen := map[string]bool{}
+	x := []string{}
+	for _, t := range tags {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		x = append(x, t)
+	}
+	return x
+}
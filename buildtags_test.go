@@ -0,0 +1,33 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_BuildTags(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{})
+	r.Equal([]string{}, e.BuildTags())
+
+	e.Set("GOFLAGS", "-mod=mod -tags=sqlite,json1")
+	r.Equal([]string{"sqlite", "json1"}, e.BuildTags())
+
+	e.Set("GOFLAGS", "'-tags=sqlite json1' -v")
+	e.Set("BUILD_TAGS", "integration, json1")
+	r.Equal([]string{"sqlite", "json1", "integration"}, e.BuildTags())
+}
+
+func Test_WithBuildTags(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithBuildTags("netgo", "osusergo,netgo"))
+	e.Set("GOFLAGS", "--tags=timetzdata")
+	r.Equal([]string{"timetzdata", "netgo", "osusergo"}, e.BuildTags())
+
+	c := e.Clone()
+	c.Set("GOFLAGS", "")
+	r.Equal([]string{"netgo", "osusergo"}, c.BuildTags())
+}
@@ -0,0 +1,32 @@
+package envy
+
+import (
+	"io/ioutil"
+	"time"
+)
+
+// CaptureDotenv writes the variables of the Env, as the process sees
+// them, to the .env file at path, to reproduce an issue with exactly
+// the environment it happened with. Only the keys filter returns true
+// for are written, all of them when it's nil. The denied keys are left
+// out, and the values of the secrets redacted, see RedactedMap. The
+// file is only readable by its owner.
+func (e *Env) CaptureDotenv(path string, filter func(key string) bool) error {
+	m := e.RedactedMap()
+	if filter != nil {
+		for k := range m {
+			if !filter(k) {
+				delete(m, k)
+			}
+		}
+	}
+	b := "# captured by envy at " + time.Now().UTC().Format(time.RFC3339) + ", with the secrets redacted\n"
+	b += FormatDotenv(m)
+	return ioutil.WriteFile(path, []byte(b), 0600)
+}
+
+// CaptureDotenv writes the variables of envy to
+// the .env file at path, see Env.CaptureDotenv.
+func CaptureDotenv(path string, filter func(key string) bool) error {
+	return env.CaptureDotenv(path, filter)
+}
@@ -0,0 +1,47 @@
+package envy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_CaptureDotenv(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"APP_NAME":     "envy",
+		"APP_PASSWORD": "hunter2",
+		"APP_INTERNAL": "x",
+		"HOME":         "/home/envy",
+	})
+	e.MarkSecret("APP_PASSWORD")
+	r.NoError(e.Deny("APP_INTERNAL"))
+
+	path := filepath.Join(t.TempDir(), ".env.captured")
+	r.NoError(e.CaptureDotenv(path, func(k string) bool {
+		return strings.HasPrefix(k, "APP_")
+	}))
+
+	b, err := ioutil.ReadFile(path)
+	r.NoError(err)
+	r.True(strings.HasPrefix(string(b), "# captured by envy at "))
+	r.True(strings.HasSuffix(string(b), "APP_NAME=envy\nAPP_PASSWORD=\"***REDACTED***\"\n"), string(b))
+
+	fi, err := os.Stat(path)
+	r.NoError(err)
+	r.Equal(os.FileMode(0600), fi.Mode().Perm())
+
+	c := New(WithOSEnv(false), WithLoader(NativeLoader{}))
+	r.NoError(c.Load(path))
+	r.Equal("envy", c.Get("APP_NAME", ""))
+
+	r.NoError(e.CaptureDotenv(path, nil))
+	b, err = ioutil.ReadFile(path)
+	r.NoError(err)
+	r.Contains(string(b), "HOME=/home/envy\n")
+}
@@ -0,0 +1,43 @@
+package envy
+
+import "strings"
+
+// WithCaseInsensitiveKeys controls whether the keys of the Env are
+// case-insensitive, like they are on Windows, where Path and PATH are
+// the same variable. When they are, Get("PATH", "") finds the value
+// of Path, and Set("PATH", v) replaces it, keeping its original case,
+// so Map and Environ still return Path.
+func WithCaseInsensitiveKeys(b bool) Option {
+	return func(e *Env) {
+		e.fold = b
+	}
+}
+
+// canon returns the key the Env keeps the value of key under: key,
+// unless the keys are case-insensitive and it holds the same key in
+// a different case. It must be called with e.moot held.
+func (e *Env) canon(key string) string {
+	if !e.fold {
+		return key
+	}
+	if _, ok := e.vars[key]; ok {
+		return key
+	}
+	if _, ok := e.sealed[key]; ok {
+		return key
+	}
+	c := key
+	found := false
+	match := func(k string) {
+		if strings.EqualFold(k, key) && (!found || k < c) {
+			c, found = k, true
+		}
+	}
+	for k := range e.vars {
+		match(k)
+	}
+	for k := range e.sealed {
+		match(k)
+	}
+	return c
+}
@@ -0,0 +1,48 @@
+package envy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithCaseInsensitiveKeys(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithCaseInsensitiveKeys(true))
+	e.Set("Path", `C:\Windows`)
+
+	r.Equal(`C:\Windows`, e.Get("PATH", ""))
+	v, ok := e.Lookup("path")
+	r.True(ok)
+	r.Equal(`C:\Windows`, v)
+
+	e.Set("PATH", `C:\Go\bin`)
+	r.Equal(map[string]string{"Path": `C:\Go\bin`}, e.Map())
+
+	dir := t.TempDir()
+	f := filepath.Join(dir, ".env")
+	r.NoError(ioutil.WriteFile(f, []byte("PATH=/usr/bin\n"), 0644))
+	r.NoError(e.Load(f))
+	r.Equal(map[string]string{"Path": "/usr/bin"}, e.Map())
+
+	e.Unset("PATH")
+	r.Empty(e.Map())
+
+	c := e.Clone()
+	c.Set("GoPath", "x")
+	r.Equal("x", c.Get("GOPATH", ""))
+}
+
+func Test_Env_CaseSensitiveKeys(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"Path": "a"})
+	_, ok := e.Lookup("PATH")
+	r.False(ok)
+
+	e.Set("PATH", "b")
+	r.Equal(map[string]string{"Path": "a", "PATH": "b"}, e.Map())
+}
@@ -0,0 +1,115 @@
+package envy
+
+import (
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// CmdEnv returns a minimal environment, suitable for exec.Cmd.Env,
+// holding only the keys starting with one of allowPrefixes, plus the
+// extra variables, which take precedence. It's sorted by key.
+//
+// Deprecated: it can't rename the keys for the subprocess, nor redact
+// them. Use ExportEnviron, with the prefixes as Keys patterns, and the
+// extra variables overlaid, see Overlay:
+//
+//	cmd.Env, err = e.Overlay(extra).ExportEnviron(envy.ExportOptions{
+//		Keys: []string{"PATH*", "HOME*", "MYAPP_*"},
+//	})
+func (e *Env) CmdEnv(allowPrefixes []string, extra map[string]string) []string {
+	m := map[string]string{}
+	for k, v := range e.Map() {
+		for _, p := range allowPrefixes {
+			if strings.HasPrefix(k, p) {
+				m[k] = v
+				break
+			}
+		}
+	}
+	for k, v := range extra {
+		m[k] = v
+	}
+	return environ(m)
+}
+
+// ScrubPolicy controls which keys ForCommand leaves out of the
+// environment of the commands.
+type ScrubPolicy int
+
+const (
+	// ScrubDenied leaves the denied keys out, see Deny.
+	// It's the default.
+	ScrubDenied ScrubPolicy = iota
+	// ScrubSecrets also leaves the secrets out, see IsSecret, like
+	// ScrubbedEnviron, for less trusted commands, such as plugins.
+	ScrubSecrets
+)
+
+// WithScrubPolicy sets which keys ForCommand leaves out.
+func WithScrubPolicy(p ScrubPolicy) Option {
+	return func(e *Env) {
+		e.scrub = p
+	}
+}
+
+// ForCommand sets the environment of cmd to the Env, with overrides
+// on top, replacing hand built append(os.Environ(), ...) code. The
+// keys are scrubbed according to the ScrubPolicy of the Env, see
+// WithScrubPolicy, unless they're overridden; use ExportEnviron for
+// subprocesses that mustn't inherit the rest of the Env.
+//
+//	cmd := exec.Command("worker")
+//	e.ForCommand(cmd, map[string]string{"GO_ENV": "test"})
+func (e *Env) ForCommand(cmd *exec.Cmd, overrides map[string]string) {
+	e.moot.RLock()
+	m := e.exported()
+	if e.scrub == ScrubSecrets {
+		for k := range m {
+			if e.isSecret(k) {
+				delete(m, k)
+			}
+		}
+	}
+	e.moot.RUnlock()
+	for k, v := range overrides {
+		m[k] = v
+	}
+	cmd.Env = environ(m)
+}
+
+// ForCommand sets the environment of cmd to envy, with overrides on top.
+func ForCommand(cmd *exec.Cmd, overrides map[string]string) {
+	env.ForCommand(cmd, overrides)
+}
+
+// ScrubbedEnviron returns the Env, without the secrets and the
+// denied keys, as a sorted list of "key=value" strings, for the
+// exec.Cmd.Env of less trusted subprocesses, such as plugins.
+func (e *Env) ScrubbedEnviron() []string {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	m := e.exported()
+	for k := range m {
+		if e.isSecret(k) {
+			delete(m, k)
+		}
+	}
+	return environ(m)
+}
+
+// ScrubbedEnviron returns envy, without the secrets and the
+// denied keys, as a sorted list of "key=value" strings.
+func ScrubbedEnviron() []string {
+	return env.ScrubbedEnviron()
+}
+
+// environ returns m as a sorted list of "key=value" strings.
+func environ(m map[string]string) []string {
+	x := make([]string, 0, len(m))
+	for k, v := range m {
+		x = append(x, k+"="+v)
+	}
+	sort.Strings(x)
+	return x
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/gobuffalo/envy"
+)
+
+func init() {
+	commands["check"] = command{
+		usage: "[-schema file] [file...]",
+		short: "validate .env files",
+		run:   checkCmd,
+	}
+}
+
+func checkCmd(c *cli, args []string) error {
+	fs := c.flagSet("check")
+	schema := fs.String("schema", "", "validate the files against the schema `file`")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{".env"}
+	}
+
+	var problems []string
+	merged := map[string]string{}
+	for _, f := range files {
+		ps, vars, err := checkFile(f)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, ps...)
+		for k, v := range vars {
+			merged[k] = v
+		}
+	}
+
+	if *schema != "" {
+		s, err := envy.LoadSchema(*schema)
+		if err != nil {
+			return err
+		}
+		for _, err := range s.Validate(merged) {
+			problems = append(problems, fmt.Sprintf("%s: %s", *schema, err))
+		}
+	}
+
+	for _, p := range problems {
+		fmt.Fprintln(c.stdout, p)
+	}
+	if len(problems) > 0 {
+		return exitError(1)
+	}
+	return nil
+}
+
+var refRx = regexp.MustCompile(`(?:^|[^\\])\$(?:\{([A-Za-z_][A-Za-z0-9_]*)\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// checkFile reports the syntax errors, duplicate keys, and references
+// to variables not defined earlier in the file, with their line
+// numbers. It also returns the variables defined in the file, read
+// with the same Loader as the other commands, see readFile, so it
+// doesn't pass files they can't load, or the other way around.
+func checkFile(path string) ([]string, map[string]string, error) {
+	var problems []string
+	vars, err := readFile(path)
+	if err != nil {
+		if !errors.Is(err, envy.ErrParse{}) {
+			return nil, nil, err
+		}
+		problems = append(problems, err.Error())
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	report := func(n int, format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf("%s:%d: %s", path, n, fmt.Sprintf(format, args...)))
+	}
+
+	lines := map[string]int{}
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		k, raw := splitLine(sc.Text())
+		if k == "" {
+			continue
+		}
+
+		if first, ok := lines[k]; ok {
+			report(n, "duplicate key %s, first defined on line %d", k, first)
+		}
+
+		if !strings.HasPrefix(raw, "'") {
+			for _, m := range refRx.FindAllStringSubmatch(raw, -1) {
+				ref := m[1] + m[2]
+				if _, ok := lines[ref]; !ok {
+					report(n, "%s references $%s, which is not defined before it", k, ref)
+				}
+			}
+		}
+		if _, ok := lines[k]; !ok {
+			lines[k] = n
+		}
+	}
+	return problems, vars, sc.Err()
+}
+
+// splitLine returns the key of a line of a .env file, and its raw
+// value, or the empty string for the blank lines, the comments, and
+// the lines without a key, whose errors are the Loader's business.
+func splitLine(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", ""
+	}
+	line = strings.TrimPrefix(line, "export ")
+	i := strings.IndexAny(line, "=:")
+	if i < 0 {
+		return "", ""
+	}
+	k := strings.TrimSpace(line[:i])
+	if k == "" || strings.ContainsAny(k, " \t") {
+		return "", ""
+	}
+	return k, strings.TrimSpace(line[i+1:])
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Check(t *testing.T) {
+	r := require.New(t)
+
+	good := writeFile(t, ".env", "# comment\nHOST=localhost\nURL=http://$HOST/\nRAW='$NOPE'\n")
+	code, stdout, _ := testCLI("check", good)
+	r.Equal(0, code)
+	r.Empty(stdout)
+
+	bad := writeFile(t, ".env", "FOO=1\nBAR\nFOO=2\nURL=http://${HOST}/\nQ=\"open\n")
+	code, stdout, _ = testCLI("check", bad)
+	r.Equal(1, code)
+	r.Equal(fmt.Sprintf(`%[1]s:2:1: can't separate key from value
+%[1]s:3: duplicate key FOO, first defined on line 1
+%[1]s:4: URL references $HOST, which is not defined before it
+`, bad), stdout)
+}
+
+func Test_Check_Loader(t *testing.T) {
+	r := require.New(t)
+
+	// godotenv, which loads the files of the other commands,
+	// takes the quote as part of the value
+	f := writeFile(t, ".env", "QUOTE='open\n")
+	code, stdout, _ := testCLI("check", f)
+	r.Equal(0, code)
+	r.Empty(stdout)
+
+	f = writeFile(t, ".env", "1FOO=x\n")
+	code, stdout, _ = testCLI("check", f)
+	r.Equal(1, code)
+	r.Contains(stdout, "1FOO: invalid key")
+}
+
+func Test_Check_Schema(t *testing.T) {
+	r := require.New(t)
+
+	schema := writeFile(t, "schema.yml", "PORT:\n  type: int\nHOST:\n  required: true\n")
+
+	f := writeFile(t, ".env", "HOST=localhost\nPORT=80\n")
+	code, _, _ := testCLI("check", "-schema", schema, f)
+	r.Equal(0, code)
+
+	f = writeFile(t, ".env", "PORT=eighty\n")
+	code, stdout, _ := testCLI("check", "-schema", schema, f)
+	r.Equal(1, code)
+	r.Equal(fmt.Sprintf("%[1]s: HOST: required but not set\n%[1]s: PORT: not a valid int\n", schema), stdout)
+}
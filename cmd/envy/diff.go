@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gobuffalo/envy"
+)
+
+func init() {
+	commands["diff"] = command{
+		usage: "[-mask] old new | -live [-mask] file",
+		short: "show the differences between env files",
+		run:   diffCmd,
+	}
+}
+
+func diffCmd(c *cli, args []string) error {
+	fs := c.flagSet("diff")
+	live := fs.Bool("live", false, "compare the file against the live environment")
+	mask := fs.Bool("mask", false, "mask the values")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var old, new map[string]string
+	switch {
+	case *live && fs.NArg() == 1:
+		f, err := readFile(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		old = f
+		new = map[string]string{}
+		e := envy.New(envy.WithAutoLoad(false))
+		// only the keys in the file matter, the live environment
+		// is full of variables that are none of its business
+		for k := range f {
+			if v, err := e.MustGet(k); err == nil {
+				new[k] = v
+			}
+		}
+	case !*live && fs.NArg() == 2:
+		var err error
+		if old, err = readFile(fs.Arg(0)); err != nil {
+			return err
+		}
+		if new, err = readFile(fs.Arg(1)); err != nil {
+			return err
+		}
+	default:
+		return errors.New("expected two files, or one file with -live")
+	}
+
+	changes := envy.Diff(old, new)
+	for _, ch := range changes {
+		o, n := ch.Old, ch.New
+		if *mask {
+			o, n = "***", "***"
+		}
+		switch ch.Op {
+		case envy.Added:
+			fmt.Fprintf(c.stdout, "+ %s=%s\n", ch.Key, n)
+		case envy.Removed:
+			fmt.Fprintf(c.stdout, "- %s=%s\n", ch.Key, o)
+		case envy.Changed:
+			fmt.Fprintf(c.stdout, "~ %s: %s -> %s\n", ch.Key, o, n)
+		}
+	}
+	if len(changes) > 0 {
+		return exitError(1)
+	}
+	return nil
+}
+
+// readFile returns only the variables defined in the .env file.
+func readFile(path string) (map[string]string, error) {
+	e := envy.New(envy.WithOSEnv(false))
+	if err := e.Load(path); err != nil {
+		return nil, err
+	}
+	return e.Map(), nil
+}
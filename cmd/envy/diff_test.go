@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Diff(t *testing.T) {
+	r := require.New(t)
+
+	a := writeFile(t, ".env.staging", "A=1\nB=2\nC=3\n")
+	b := writeFile(t, ".env.production", "A=1\nB=two\nD=4\n")
+
+	code, stdout, _ := testCLI("diff", a, b)
+	r.Equal(1, code)
+	r.Equal("~ B: 2 -> two\n- C=3\n+ D=4\n", stdout)
+
+	code, stdout, _ = testCLI("diff", "-mask", a, b)
+	r.Equal(1, code)
+	r.Equal("~ B: *** -> ***\n- C=***\n+ D=***\n", stdout)
+
+	code, stdout, _ = testCLI("diff", a, a)
+	r.Equal(0, code)
+	r.Empty(stdout)
+
+	code, _, _ = testCLI("diff", a)
+	r.Equal(1, code)
+}
+
+func Test_Diff_Live(t *testing.T) {
+	r := require.New(t)
+
+	r.NoError(os.Setenv("ENVY_CLI_LIVE", "live"))
+	defer os.Unsetenv("ENVY_CLI_LIVE")
+
+	a := writeFile(t, ".env", "ENVY_CLI_LIVE=file\nENVY_CLI_GONE=x\n")
+	code, stdout, _ := testCLI("diff", "-live", a)
+	r.Equal(1, code)
+	r.Equal("- ENVY_CLI_GONE=x\n~ ENVY_CLI_LIVE: file -> live\n", stdout)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gobuffalo/envy"
+)
+
+func init() {
+	commands["doctor"] = command{
+		usage: "",
+		short: "diagnose the Go environment",
+		run:   doctorCmd,
+	}
+}
+
+func doctorCmd(c *cli, args []string) error {
+	fs := c.flagSet("doctor")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var problems []string
+	w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	show := func(k, v string) {
+		if v == "" {
+			v = "(unset)"
+		}
+		fmt.Fprintf(w, "%s:\t%s\n", k, v)
+	}
+
+	gp := envy.GoPath()
+	show("GOPATH", gp)
+	show("GOPATHS", strings.Join(envy.GoPaths(), ", "))
+	if gp == "" {
+		problems = append(problems, "GOPATH is empty")
+	}
+
+	mods := envy.Get(envy.GO111MODULE, "")
+	show(envy.GO111MODULE, mods)
+
+	goBin, err := exec.LookPath(envy.GoBin())
+	if err != nil {
+		show("go binary", envy.GoBin()+" (not found)")
+		problems = append(problems, fmt.Sprintf("could not find the go binary %q", envy.GoBin()))
+	} else {
+		v, _ := envy.GoVersion()
+		show("go binary", fmt.Sprintf("%s (%s)", goBin, v))
+	}
+
+	mod, err := envy.CurrentModule()
+	if err != nil {
+		show("module", "none")
+	} else {
+		show("module", mod)
+		if mods == "off" {
+			problems = append(problems, "GO111MODULE=off, but inside the module "+mod)
+		}
+	}
+
+	work := "none"
+	if goEnv, err := envy.GoEnv(); err == nil {
+		if s := goEnv["GOWORK"]; s != "" && s != "off" {
+			work = s
+		}
+	}
+	show("go.work", work)
+	w.Flush()
+
+	if len(problems) == 0 {
+		return nil
+	}
+	fmt.Fprintln(c.stdout)
+	for _, p := range problems {
+		fmt.Fprintf(c.stdout, "problem: %s\n", p)
+	}
+	return exitError(1)
+}
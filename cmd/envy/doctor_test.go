@@ -0,0 +1,47 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gobuffalo/envy"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Doctor(t *testing.T) {
+	r := require.New(t)
+	envy.TempT(t)
+	envy.Set("GOPATH", "/go")
+	envy.Set(envy.GO111MODULE, "on")
+
+	code, stdout, _ := testCLI("doctor")
+	r.Equal(0, code)
+	r.Contains(stdout, "GOPATH:       /go\n")
+	r.Contains(stdout, "GO111MODULE:  on\n")
+	r.Contains(stdout, "module:       github.com/gobuffalo/envy\n")
+	r.NotContains(stdout, "problem:")
+}
+
+func Test_Doctor_Problems(t *testing.T) {
+	r := require.New(t)
+	envy.TempT(t)
+	envy.Set("GOPATH", "")
+	envy.Set(envy.GO111MODULE, "off")
+	envy.Set("GO_BIN", "envy-no-such-go")
+
+	pwd, err := os.Getwd()
+	r.NoError(err)
+	dir := t.TempDir()
+	r.NoError(ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0644))
+	r.NoError(os.Chdir(dir))
+	defer os.Chdir(pwd)
+
+	code, stdout, _ := testCLI("doctor")
+	r.Equal(1, code)
+	r.Contains(stdout, "module:       example.com/app\n")
+	r.Contains(stdout, "problem: GOPATH is empty\n")
+	r.Contains(stdout, `problem: could not find the go binary "envy-no-such-go"`)
+	r.Contains(stdout, "problem: GO111MODULE=off, but inside the module example.com/app\n")
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/gobuffalo/envy"
+)
+
+func init() {
+	commands["encrypt"] = command{
+		usage: "[file] [-key-file file]",
+		short: "encrypt the values of a .env file",
+		run:   encryptCmd,
+	}
+	commands["decrypt"] = command{
+		usage: "[file] [-key-file file]",
+		short: "decrypt the values of a .env file",
+		run:   decryptCmd,
+	}
+}
+
+func encryptCmd(c *cli, args []string) error {
+	path, keyFile, err := cryptArgs(c, "encrypt", args)
+	if err != nil {
+		return err
+	}
+
+	key, err := envy.ReadKeyFile(keyFile)
+	if os.IsNotExist(err) {
+		if key, err = envy.GenerateKey(); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(keyFile, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.stderr, "generated a new key in %s, keep it safe and out of version control\n", keyFile)
+	}
+	if err != nil {
+		return err
+	}
+	return envy.EncryptFile(path, key)
+}
+
+func decryptCmd(c *cli, args []string) error {
+	path, keyFile, err := cryptArgs(c, "decrypt", args)
+	if err != nil {
+		return err
+	}
+
+	key, err := envy.ReadKeyFile(keyFile)
+	if err != nil {
+		return err
+	}
+	return envy.DecryptFile(path, key)
+}
+
+func cryptArgs(c *cli, name string, args []string) (string, string, error) {
+	fs := c.flagSet(name)
+	keyFile := fs.String("key-file", ".env.key", "the `file` holding the hex encoded key")
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return "", "", err
+	}
+	switch len(pos) {
+	case 0:
+		return ".env", *keyFile, nil
+	case 1:
+		return pos[0], *keyFile, nil
+	}
+	return "", "", errors.New("expected a single file")
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Encrypt_Decrypt(t *testing.T) {
+	r := require.New(t)
+
+	f := writeFile(t, ".env", "# secrets\nTOKEN=hunter2\n")
+	kf := filepath.Join(t.TempDir(), "key.txt")
+
+	code, _, stderr := testCLI("decrypt", f, "--key-file", kf)
+	r.Equal(1, code)
+	r.Contains(stderr, "key.txt")
+
+	code, _, stderr = testCLI("encrypt", f, "--key-file", kf)
+	r.Equal(0, code)
+	r.Contains(stderr, "generated a new key")
+
+	b, err := ioutil.ReadFile(f)
+	r.NoError(err)
+	r.NotContains(string(b), "hunter2")
+
+	code, _, _ = testCLI("decrypt", f, "--key-file", kf)
+	r.Equal(0, code)
+
+	b, err = ioutil.ReadFile(f)
+	r.NoError(err)
+	r.Equal("# secrets\nTOKEN=hunter2\n", string(b))
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/gobuffalo/envy"
+)
+
+func init() {
+	commands["exec"] = command{
+		usage: "[-env profile] -- command [args...]",
+		short: "run a command with a profile's env files",
+		run:   execCmd,
+	}
+}
+
+func execCmd(c *cli, args []string) error {
+	fs := c.flagSet("exec")
+	profile := fs.String("env", "", "the `profile` to load, defaults to GO_ENV or development")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	argv := fs.Args()
+	if len(argv) == 0 {
+		return errors.New("missing command to run")
+	}
+
+	e := envy.New(envy.WithAutoLoad(false))
+	if err := e.LoadProfile(*profile); err != nil {
+		return err
+	}
+	return execEnv(c, e.Environ(), argv)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Exec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	r := require.New(t)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		".env":                  "ENVY_CLI_A=base\nENVY_CLI_B=base\nENVY_CLI_C=base\n",
+		".env.production":       "ENVY_CLI_B=production\nENVY_CLI_C=production\n",
+		".env.production.local": "ENVY_CLI_C=local\n",
+		".env.development":      "ENVY_CLI_B=development\n",
+	}
+	for n, c := range files {
+		r.NoError(ioutil.WriteFile(filepath.Join(dir, n), []byte(c), 0644))
+	}
+
+	pwd, err := os.Getwd()
+	r.NoError(err)
+	r.NoError(os.Chdir(dir))
+	defer os.Chdir(pwd)
+
+	code, stdout, _ := testCLI("exec", "--env", "production", "--", "sh", "-c", "echo $ENVY_CLI_A $ENVY_CLI_B $ENVY_CLI_C")
+	r.Equal(0, code)
+	r.Equal("base production local\n", stdout)
+
+	code, stdout, _ = testCLI("exec", "-env", "development", "--", "sh", "-c", "echo $ENVY_CLI_A $ENVY_CLI_B $ENVY_CLI_C")
+	r.Equal(0, code)
+	r.Equal("base development base\n", stdout)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gobuffalo/envy"
+)
+
+func init() {
+	commands["export"] = command{
+		usage: "[-format f] [-redact pattern]... [-redact-secrets] [-f file]...",
+		short: "print the loaded env",
+		run:   exportCmd,
+	}
+}
+
+func exportCmd(c *cli, args []string) error {
+	fs := c.flagSet("export")
+	var files, redact fileList
+	format := fs.String("format", "dotenv", "output `format`: shell, json, yaml, or dotenv")
+	fs.Var(&files, "f", "load the .env `file`, may be repeated")
+	fs.Var(&redact, "redact", "redact the values of keys matching the glob `pattern`, may be repeated")
+	secrets := fs.Bool("redact-secrets", false, "redact the values of keys that look like secrets, such as *_TOKEN")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	e, err := loadEnv(files)
+	if err != nil {
+		return err
+	}
+	return export(c.stdout, e, *format, envy.ExportOptions{
+		Redact:        redact,
+		RedactSecrets: *secrets,
+	})
+}
+
+func export(w io.Writer, e *envy.Env, format string, opts envy.ExportOptions) error {
+	switch format {
+	case "json":
+		return e.ToJSON(w, opts)
+	case "yaml":
+		return e.ToYAML(w, opts)
+	case "shell":
+		return e.ToShell(w, opts)
+	case "dotenv":
+		m, err := e.Export(opts)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, envy.FormatDotenv(m))
+		return err
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
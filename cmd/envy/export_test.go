@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gobuffalo/envy"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Export(t *testing.T) {
+	m := map[string]string{
+		"B":     "it's",
+		"A":     "1\n$2",
+		"TRUE":  "true",
+		"EMPTY": "",
+	}
+
+	table := []struct {
+		format string
+		out    string
+	}{
+		{"shell", "export A='1\n$2'\nexport B='it'\\''s'\nexport EMPTY=''\nexport TRUE='true'\n"},
+		{"json", "{\n  \"A\": \"1\\n$2\",\n  \"B\": \"it's\",\n  \"EMPTY\": \"\",\n  \"TRUE\": \"true\"\n}\n"},
+		{"yaml", "A: |-\n  1\n  $2\nB: it's\nEMPTY: \"\"\n\"TRUE\": \"true\"\n"},
+		{"dotenv", "A=\"1\\n\\$2\"\nB=\"it's\"\nEMPTY=\nTRUE=true\n"},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.format, func(st *testing.T) {
+			r := require.New(st)
+			bb := &bytes.Buffer{}
+			r.NoError(export(bb, envy.FromMap(m), tt.format, envy.ExportOptions{}))
+			r.Equal(tt.out, bb.String())
+		})
+	}
+
+	require.Error(t, export(&bytes.Buffer{}, envy.FromMap(m), "xml", envy.ExportOptions{}))
+}
+
+func Test_Export_Cmd(t *testing.T) {
+	r := require.New(t)
+
+	f := writeFile(t, ".env", "ENVY_CLI_API_TOKEN=hunter2\nENVY_CLI_A=a\n")
+
+	code, stdout, _ := testCLI("export", "-format", "shell", "-redact", "*_TOKEN", "-f", f)
+	r.Equal(0, code)
+	r.Contains(stdout, "export ENVY_CLI_A='a'\n")
+	r.Contains(stdout, "export ENVY_CLI_API_TOKEN='***REDACTED***'\n")
+	r.NotContains(stdout, "hunter2")
+
+	code, stdout, _ = testCLI("export", "-format", "json", "-redact-secrets", "-f", f)
+	r.Equal(0, code)
+	r.Contains(stdout, `"ENVY_CLI_API_TOKEN": "***REDACTED***"`)
+
+	code, _, _ = testCLI("export", "-redact", "[", "-f", f)
+	r.Equal(1, code)
+}
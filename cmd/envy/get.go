@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gobuffalo/envy"
+)
+
+func init() {
+	commands["get"] = command{
+		usage: "KEY [-f file]...",
+		short: "print the value of a key",
+		run:   getCmd,
+	}
+	commands["set"] = command{
+		usage: "KEY VALUE [-file file]",
+		short: "set a key in a .env file",
+		run:   setCmd,
+	}
+}
+
+func getCmd(c *cli, args []string) error {
+	fs := c.flagSet("get")
+	var files fileList
+	fs.Var(&files, "f", "load the .env `file`, may be repeated")
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return errors.New("expected a single KEY")
+	}
+
+	e, err := loadEnv(files)
+	if err != nil {
+		return err
+	}
+	v, err := e.MustGet(pos[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout, v)
+	return nil
+}
+
+func setCmd(c *cli, args []string) error {
+	fs := c.flagSet("set")
+	file := fs.String("file", ".env", "the .env `file` to modify")
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 2 {
+		return errors.New("expected a KEY and a VALUE")
+	}
+	return envy.UpdateFile(*file, map[string]string{pos[0]: pos[1]})
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Get(t *testing.T) {
+	r := require.New(t)
+
+	f := writeFile(t, ".env", "ENVY_CLI_GET=got it\n")
+
+	code, stdout, _ := testCLI("get", "ENVY_CLI_GET", "-f", f)
+	r.Equal(0, code)
+	r.Equal("got it\n", stdout)
+
+	code, _, stderr := testCLI("get", "-f", f, "ENVY_CLI_NOPE")
+	r.Equal(1, code)
+	r.Contains(stderr, "ENVY_CLI_NOPE")
+}
+
+func Test_Set(t *testing.T) {
+	r := require.New(t)
+
+	f := writeFile(t, ".env", "# keep me\nA=1\n")
+
+	code, _, _ := testCLI("set", "A", "2", "--file", f)
+	r.Equal(0, code)
+	code, _, _ = testCLI("set", "-file", f, "B", "two words")
+	r.Equal(0, code)
+	code, _, _ = testCLI("set", "-file", f, "--", "C", "-3")
+	r.Equal(0, code)
+
+	b, err := ioutil.ReadFile(f)
+	r.NoError(err)
+	r.Equal("# keep me\nA=2\nB=\"two words\"\nC=-3\n", string(b))
+
+	code, _, _ = testCLI("set", "-file", filepath.Join(t.TempDir(), ".env"), "A")
+	r.Equal(1, code)
+}
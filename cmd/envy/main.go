@@ -0,0 +1,158 @@
+/*
+envy is a command line tool for working with ENV variables and .env
+files. It's built on the github.com/gobuffalo/envy package, so it
+shares the exact same semantics as Go applications using it.
+
+	envy run -f .env -f .env.local -- mycmd args...
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gobuffalo/envy"
+	"golang.org/x/term"
+)
+
+type command struct {
+	usage string
+	short string
+	run   func(c *cli, args []string) error
+}
+
+var commands = map[string]command{}
+
+// cli holds the standard streams the commands work with.
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	// tty is true when the user can be prompted for input
+	tty bool
+}
+
+// exitError makes the envy binary exit with the given code.
+type exitError int
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+func main() {
+	c := &cli{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		tty:    term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd())),
+	}
+	os.Exit(c.main(os.Args[1:]))
+}
+
+func (c *cli) main(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		c.usage()
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(c.stderr, "envy: unknown command %q\n", args[0])
+		c.usage()
+		return 2
+	}
+
+	err := cmd.run(c, args[1:])
+	var ee exitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &ee):
+		return int(ee)
+	}
+	fmt.Fprintf(c.stderr, "envy %s: %s\n", args[0], err)
+	return 1
+}
+
+func (c *cli) usage() {
+	names := make([]string, 0, len(commands))
+	for n := range commands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(c.stderr, "usage: envy <command> [arguments]")
+	fmt.Fprintln(c.stderr)
+	fmt.Fprintln(c.stderr, "commands:")
+	w := tabwriter.NewWriter(c.stderr, 0, 4, 2, ' ', 0)
+	for _, n := range names {
+		fmt.Fprintf(w, "  %s %s\t%s\n", n, commands[n].usage, commands[n].short)
+	}
+	w.Flush()
+}
+
+func (c *cli) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("envy "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+// parseInterspersed parses args with fs, allowing flags to follow
+// the positional arguments, which it returns.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return pos, nil
+		}
+		// everything after a "--" is positional
+		if n := len(args) - len(rest); n > 0 && args[n-1] == "--" {
+			return append(pos, rest...), nil
+		}
+		pos = append(pos, rest[0])
+		args = rest[1:]
+	}
+}
+
+// fileList is a flag.Value collecting repeated file flags.
+type fileList []string
+
+func (f *fileList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileList) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+// loadEnv returns the OS environment merged with the given files,
+// in order. When no files are given, .env is loaded if it exists.
+// The .env the envy package loads when it's initialized is left out,
+// so it's only loaded when no files are given, and the OS environment
+// of the envy process itself is left untouched.
+func loadEnv(files []string) (*envy.Env, error) {
+	e := envy.New(envy.WithAutoLoad(false))
+	if len(files) == 0 {
+		if _, err := os.Stat(".env"); err != nil {
+			return e, nil
+		}
+		files = []string{".env"}
+	}
+	if err := e.Load(files...); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testCLI runs the envy command with args, returning its
+// exit code, stdout, and stderr.
+func testCLI(args ...string) (int, string, string) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	c := &cli{
+		stdin:  strings.NewReader(""),
+		stdout: stdout,
+		stderr: stderr,
+	}
+	code := c.main(args)
+	return code, stdout.String(), stderr.String()
+}
+
+// writeFile writes a file named name with the given content in
+// a temporary directory, returning its path.
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func Test_Main_Usage(t *testing.T) {
+	r := require.New(t)
+
+	code, _, stderr := testCLI()
+	r.Equal(2, code)
+	r.Contains(stderr, "usage: envy")
+
+	code, _, stderr = testCLI("nope")
+	r.Equal(2, code)
+	r.Contains(stderr, `unknown command "nope"`)
+}
+
+func Test_loadEnv(t *testing.T) {
+	r := require.New(t)
+
+	a := writeFile(t, ".env", "ENVY_CLI_A=a\nENVY_CLI_B=a\n")
+	b := writeFile(t, ".env.local", "ENVY_CLI_B=b\n")
+
+	e, err := loadEnv([]string{a, b})
+	r.NoError(err)
+	r.Equal("a", e.Get("ENVY_CLI_A", ""))
+	r.Equal("b", e.Get("ENVY_CLI_B", ""))
+	r.Zero(os.Getenv("ENVY_CLI_A"))
+
+	_, err = loadEnv([]string{a, "nope.env"})
+	r.Error(err)
+}
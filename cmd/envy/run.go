@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/gobuffalo/envy"
+)
+
+func init() {
+	commands["run"] = command{
+		usage: "[-f file]... -- command [args...]",
+		short: "run a command with the loaded env",
+		run:   runCmd,
+	}
+}
+
+func runCmd(c *cli, args []string) error {
+	fs := c.flagSet("run")
+	var files fileList
+	fs.Var(&files, "f", "load the .env `file`, may be repeated")
+	schema := fs.String("require-from", "", "prompt for the required keys of the `schema` that are missing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	argv := fs.Args()
+	if len(argv) == 0 {
+		return errors.New("missing command to run")
+	}
+
+	e, err := loadEnv(files)
+	if err != nil {
+		return err
+	}
+
+	if *schema != "" {
+		s, err := envy.LoadSchema(*schema)
+		if err != nil {
+			return err
+		}
+		if !c.tty {
+			if missing := e.MissingKeys(s); len(missing) > 0 {
+				return fmt.Errorf("missing required ENV vars: %s", strings.Join(missing, ", "))
+			}
+		} else if err := e.Prompt(s, envy.PromptOptions{In: c.stdin, Out: c.stderr}); err != nil {
+			return err
+		}
+		if errs := s.Validate(e.Map()); len(errs) > 0 {
+			for _, err := range errs {
+				fmt.Fprintf(c.stderr, "envy run: %s\n", err)
+			}
+			return exitError(1)
+		}
+	}
+	return execEnv(c, e.Environ(), argv)
+}
+
+// execEnv runs argv with the given environment, connected to the
+// cli's streams, and returns the command's exit status as an exitError.
+func execEnv(c *cli, environ []string, argv []string) error {
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Env = environ
+	cmd.Stdin = c.stdin
+	cmd.Stdout = c.stdout
+	cmd.Stderr = c.stderr
+
+	err := cmd.Run()
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		return exitError(ee.ExitCode())
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Run(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	r := require.New(t)
+
+	a := writeFile(t, ".env", "ENVY_CLI_A=a\nENVY_CLI_B=a\n")
+	b := writeFile(t, ".env.local", "ENVY_CLI_B=b\n")
+
+	code, stdout, _ := testCLI("run", "-f", a, "-f", b, "--", "sh", "-c", "echo $ENVY_CLI_A$ENVY_CLI_B")
+	r.Equal(0, code)
+	r.Equal("ab\n", stdout)
+
+	code, _, _ = testCLI("run", "-f", a, "--", "sh", "-c", "exit 3")
+	r.Equal(3, code)
+
+	code, _, stderr := testCLI("run", "-f", a)
+	r.Equal(1, code)
+	r.Contains(stderr, "missing command")
+}
+
+func Test_Run_RequireFrom(t *testing.T) {
+	r := require.New(t)
+
+	schema := writeFile(t, "schema.yml", "ENVY_CLI_REQUIRED:\n  required: true\n")
+	code, _, stderr := testCLI("run", "--require-from", schema, "--", "true")
+	r.Equal(1, code)
+	r.Contains(stderr, "missing required ENV vars: ENVY_CLI_REQUIRED")
+}
+
+func Test_Run_RequireFrom_Prompt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	r := require.New(t)
+
+	schema := writeFile(t, "schema.yml", "ENVY_CLI_REQUIRED:\n  required: true\n  description: needed\n")
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	c := &cli{
+		stdin:  strings.NewReader("yes\nn\n"),
+		stdout: stdout,
+		stderr: stderr,
+		tty:    true,
+	}
+	code := c.main([]string{"run", "--require-from", schema, "--", "sh", "-c", "echo $ENVY_CLI_REQUIRED"})
+	r.Equal(0, code, stderr.String())
+	r.Equal("yes\n", stdout.String())
+	r.Contains(stderr.String(), "ENVY_CLI_REQUIRED (needed): ")
+}
+
+func Test_Run_AutoLoadedEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	if prod := os.Getenv("ENVY_CLI_PROD"); prod != "" {
+		// the envy package has loaded the .env of the cwd by now
+		_, stdout, stderr := testCLI("run", "-f", prod, "--", "sh", "-c", "echo ${ENVY_CLI_CONFLICT:-unset}")
+		fmt.Print(stdout, stderr)
+		return
+	}
+	r := require.New(t)
+
+	prod := writeFile(t, "prod.env", "ENVY_CLI_ONLY=prod\n")
+	dir := t.TempDir()
+	r.NoError(ioutil.WriteFile(filepath.Join(dir, ".env"), []byte("ENVY_CLI_CONFLICT=cwd\n"), 0644))
+
+	cmd := exec.Command(os.Args[0], "-test.run=^Test_Run_AutoLoadedEnv$")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "ENVY_CLI_PROD="+prod)
+	out, err := cmd.CombinedOutput()
+	r.NoError(err, string(out))
+	r.True(strings.HasPrefix(string(out), "unset\n"), string(out))
+}
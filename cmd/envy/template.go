@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/gobuffalo/envy"
+)
+
+func init() {
+	commands["template"] = command{
+		usage: "[-strict] [-f file]... template",
+		short: "render a text/template with the loaded env",
+		run:   templateCmd,
+	}
+}
+
+func templateCmd(c *cli, args []string) error {
+	fs := c.flagSet("template")
+	var files fileList
+	fs.Var(&files, "f", "load the .env `file`, may be repeated")
+	strict := fs.Bool("strict", false, "fail on references to missing keys")
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return errors.New("expected a single template file, or - for stdin")
+	}
+
+	in := c.stdin
+	if pos[0] != "-" {
+		f, err := os.Open(pos[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	e, err := loadEnv(files)
+	if err != nil {
+		return err
+	}
+	return e.RenderTemplateWith(in, c.stdout, envy.RenderOptions{
+		Name:   filepath.Base(pos[0]),
+		Strict: *strict,
+	})
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Template(t *testing.T) {
+	r := require.New(t)
+
+	f := writeFile(t, ".env", "ENVY_CLI_HOST=example.com\n")
+	tmpl := writeFile(t, "config.tmpl", "host: {{ .ENVY_CLI_HOST }}\nport: {{ envOr \"ENVY_CLI_PORT\" \"80\" }}\nenv: {{ env \"ENVY_CLI_HOST\" }}\n")
+
+	code, stdout, _ := testCLI("template", tmpl, "-f", f)
+	r.Equal(0, code)
+	r.Equal("host: example.com\nport: 80\nenv: example.com\n", stdout)
+
+	missing := writeFile(t, "missing.tmpl", "{{ .ENVY_CLI_MISSING }}")
+	code, stdout, _ = testCLI("template", "-f", f, missing)
+	r.Equal(0, code)
+	r.Equal("<no value>", stdout)
+
+	code, _, stderr := testCLI("template", "-strict", "-f", f, missing)
+	r.Equal(1, code)
+	r.Contains(stderr, "ENVY_CLI_MISSING")
+}
@@ -0,0 +1,87 @@
+package envy
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_CmdEnv(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"PATH":        "/bin",
+		"MYAPP_DB":    "db",
+		"MYAPP_MODE":  "web",
+		"AWS_SECRET":  "hunter2",
+		"PATHOLOGIST": "x",
+	})
+
+	r.Equal([]string{
+		"MYAPP_DB=db",
+		"MYAPP_MODE=worker",
+		"PATH=/bin",
+		"PATHOLOGIST=x",
+		"WORKER_ID=1",
+	}, e.CmdEnv([]string{"PATH", "MYAPP_"}, map[string]string{
+		"MYAPP_MODE": "worker",
+		"WORKER_ID":  "1",
+	}))
+
+	r.Empty(e.CmdEnv(nil, nil))
+	r.Len(e.CmdEnv([]string{""}, nil), 5)
+
+	// its replacement
+	x, err := e.Overlay(map[string]string{
+		"MYAPP_MODE": "worker",
+		"WORKER_ID":  "1",
+	}).ExportEnviron(ExportOptions{Keys: []string{"PATH*", "MYAPP_*", "WORKER_ID"}})
+	r.NoError(err)
+	r.Equal(e.CmdEnv([]string{"PATH", "MYAPP_"}, map[string]string{
+		"MYAPP_MODE": "worker",
+		"WORKER_ID":  "1",
+	}), x)
+}
+
+func Test_Env_ScrubbedEnviron(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"PATH":              "/bin",
+		"API_TOKEN":         "abc",
+		"DATABASE_URL":      "postgres://",
+		"AWS_SESSION_TOKEN": "session",
+		"LD_PRELOAD":        "evil.so",
+	})
+	e.MarkSecret("DATABASE_URL")
+	r.NoError(e.Deny("LD_PRELOAD"))
+
+	r.Equal([]string{"PATH=/bin"}, e.ScrubbedEnviron())
+}
+
+func Test_Env_ForCommand(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"PATH":     "/bin",
+		"GO_ENV":   "development",
+		"INTERNAL": "x",
+		"TOKEN":    "y",
+	})
+	r.NoError(e.Deny("INTERNAL", "TOKEN"))
+
+	cmd := exec.Command("true")
+	e.ForCommand(cmd, map[string]string{"GO_ENV": "test", "TOKEN": "z"})
+	r.Equal([]string{"GO_ENV=test", "PATH=/bin", "TOKEN=z"}, cmd.Env)
+
+	// the Env is left alone
+	r.Equal("development", e.Get("GO_ENV", ""))
+
+	e = New(WithOSEnv(false), WithScrubPolicy(ScrubSecrets))
+	e.Set("PATH", "/bin")
+	e.Set("API_TOKEN", "y")
+	e.Set("DB_PASSWORD", "z")
+	e.Clone().ForCommand(cmd, map[string]string{"DB_PASSWORD": "plugin"})
+	r.Equal([]string{"DB_PASSWORD=plugin", "PATH=/bin"}, cmd.Env)
+}
@@ -0,0 +1,67 @@
+/*
+Package cobrax binds the flags of cobra commands to envy, so a flag,
+such as --db-host, can also be set with an ENV variable, such as
+MYAPP_DB_HOST. The command line takes precedence over the ENV, which
+takes precedence over the flags' defaults.
+
+It's its own module, so envy itself doesn't depend on cobra.
+*/
+package cobrax
+
+import (
+	"github.com/gobuffalo/envy"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// SourceAnnotation is the flag annotation recording the ENV
+// variable the flag got its value from, and that value.
+const SourceAnnotation = "envy_source"
+
+// BindCommand binds the persistent flags of cmd to the default Env,
+// see Bind.
+func BindCommand(cmd *cobra.Command, prefix string) error {
+	return Bind(envy.Default(), cmd, prefix)
+}
+
+// Bind sets the persistent flags of cmd that haven't been changed
+// yet from their ENV variables in e, see envy.FlagKey, recording
+// where their values came from, see Source. It must be called before
+// the command is executed.
+//
+//	root := &cobra.Command{Use: "myapp"}
+//	root.PersistentFlags().String("db-host", "localhost", "database host")
+//	if err := cobrax.BindCommand(root, "MYAPP"); err != nil {
+//		log.Fatal(err)
+//	}
+//	root.Execute()
+func Bind(e *envy.Env, cmd *cobra.Command, prefix string) error {
+	fs := cmd.PersistentFlags()
+	var names []string
+	fs.VisitAll(func(f *pflag.Flag) {
+		names = append(names, f.Name)
+	})
+	bound, err := e.BindFlags(prefix, names, fs.Changed, fs.Set)
+	for name, key := range bound {
+		fs.SetAnnotation(name, SourceAnnotation, []string{key, fs.Lookup(name).Value.String()})
+	}
+	return err
+}
+
+// Source returns the ENV variable the flag name of cmd got its value
+// from, or an empty string if it didn't get it from the ENV, or the
+// value was then replaced on the command line.
+func Source(cmd *cobra.Command, name string) string {
+	f := cmd.Flags().Lookup(name)
+	if f == nil {
+		f = cmd.PersistentFlags().Lookup(name)
+	}
+	if f == nil {
+		return ""
+	}
+	a := f.Annotations[SourceAnnotation]
+	if len(a) != 2 || f.Value.String() != a[1] {
+		return ""
+	}
+	return a[0]
+}
@@ -0,0 +1,38 @@
+package cobrax
+
+import (
+	"testing"
+
+	"github.com/gobuffalo/envy"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Bind(t *testing.T) {
+	r := require.New(t)
+
+	e := envy.FromMap(map[string]string{
+		"MYAPP_DB_HOST": "db.example.com",
+		"MYAPP_PORT":    "4000",
+	})
+
+	var host string
+	var port int
+	root := &cobra.Command{
+		Use: "myapp",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+	root.PersistentFlags().StringVar(&host, "db-host", "localhost", "")
+	root.PersistentFlags().IntVar(&port, "port", 3000, "")
+
+	r.NoError(Bind(e, root, "MYAPP"))
+	root.SetArgs([]string{"--port", "5000"})
+	r.NoError(root.Execute())
+
+	r.Equal("db.example.com", host)
+	r.Equal(5000, port)
+	r.Equal("MYAPP_DB_HOST", Source(root, "db-host"))
+	r.Equal("", Source(root, "port"))
+}
@@ -0,0 +1,88 @@
+package envy
+
+import "context"
+
+type ctxKey struct{}
+
+type ctxValuesKey struct{}
+
+// NewContext returns a copy of ctx carrying e, so code handling a
+// request, or a job, reads the configuration scoped to it through
+// FromContext, such as an Env overlaid with the settings of a tenant.
+func NewContext(ctx context.Context, e *Env) context.Context {
+	return context.WithValue(ctx, ctxKey{}, e)
+}
+
+// FromContext returns the Env carried by ctx, see
+// NewContext, or the default Env when there's none.
+func FromContext(ctx context.Context) *Env {
+	if e, ok := ctx.Value(ctxKey{}).(*Env); ok && e != nil {
+		return e
+	}
+	return env
+}
+
+// Overlay returns a copy of the Env, like Clone, with the given
+// values set on top of the Env's own. With no values, the Env
+// itself is returned, rather than a copy.
+func (e *Env) Overlay(values map[string]string) *Env {
+	if len(values) == 0 {
+		return e
+	}
+	c := e.Clone()
+	for k, v := range values {
+		c.Set(k, v)
+	}
+	return c
+}
+
+// ContextWithValues returns a copy of ctx carrying values, which
+// GetContext and LookupContext resolve before the keys of the Env, on
+// top of those ctx already carries. Concurrent workers can so each
+// see their own values for the same keys, without a copy of the Env,
+// and without changing it, which Temp can't offer.
+func ContextWithValues(ctx context.Context, values map[string]string) context.Context {
+	m := map[string]string{}
+	if parent, ok := ctx.Value(ctxValuesKey{}).(map[string]string); ok {
+		for k, v := range parent {
+			m[k] = v
+		}
+	}
+	for k, v := range values {
+		m[k] = v
+	}
+	return context.WithValue(ctx, ctxValuesKey{}, m)
+}
+
+// LookupContext looks key up in the values carried by ctx, see
+// ContextWithValues, and then in the Env.
+func (e *Env) LookupContext(ctx context.Context, key string) (string, bool) {
+	if m, ok := ctx.Value(ctxValuesKey{}).(map[string]string); ok {
+		if v, ok := m[key]; ok {
+			e.access(key, true)
+			return v, true
+		}
+	}
+	return e.lookup(key)
+}
+
+// GetContext is like Get, but resolves the values carried
+// by ctx first, see ContextWithValues.
+func (e *Env) GetContext(ctx context.Context, key string, value string) string {
+	if v, ok := e.LookupContext(ctx, key); ok {
+		return v
+	}
+	return value
+}
+
+// LookupContext looks key up in the values
+// carried by ctx, and then in envy.
+func LookupContext(ctx context.Context, key string) (string, bool) {
+	return env.LookupContext(ctx, key)
+}
+
+// GetContext is like Get, but resolves the
+// values carried by ctx first.
+func GetContext(ctx context.Context, key string, value string) string {
+	return env.GetContext(ctx, key, value)
+}
@@ -0,0 +1,78 @@
+package envy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Context(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(Default(), FromContext(context.Background()))
+
+	e := FromMap(map[string]string{"TENANT": "acme"})
+	ctx := NewContext(context.Background(), e)
+	r.Equal(e, FromContext(ctx))
+	r.Equal("acme", FromContext(ctx).Get("TENANT", ""))
+}
+
+func Test_Env_Overlay(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"TENANT": "default", "PORT": "3000"})
+	e.MarkSecret("DB_PASS")
+
+	o := e.Overlay(map[string]string{"TENANT": "acme", "DB_PASS": "x"})
+	r.Equal("acme", o.Get("TENANT", ""))
+	r.Equal("3000", o.Get("PORT", ""))
+	r.True(o.IsSecret("DB_PASS"))
+	r.Equal("default", e.Get("TENANT", ""))
+
+	r.Same(e, e.Overlay(nil))
+}
+
+func Test_Env_GetContext(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"TENANT": "default", "PORT": "3000"})
+	ctx := context.Background()
+	r.Equal("default", e.GetContext(ctx, "TENANT", ""))
+
+	ctx = ContextWithValues(ctx, map[string]string{"TENANT": "acme"})
+	r.Equal("acme", e.GetContext(ctx, "TENANT", ""))
+	r.Equal("3000", e.GetContext(ctx, "PORT", ""))
+	r.Equal("x", e.GetContext(ctx, "NOPE", "x"))
+
+	inner := ContextWithValues(ctx, map[string]string{"PORT": "4000"})
+	v, ok := e.LookupContext(inner, "TENANT")
+	r.True(ok)
+	r.Equal("acme", v)
+	r.Equal("4000", e.GetContext(inner, "PORT", ""))
+	r.Equal("3000", e.GetContext(ctx, "PORT", ""))
+
+	r.Equal("default", e.Get("TENANT", ""))
+}
+
+func Test_Env_GetContext_Concurrent(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"WORKER": "none"})
+	got := make([]string, 10)
+	var wg sync.WaitGroup
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := ContextWithValues(context.Background(), map[string]string{"WORKER": fmt.Sprint(i)})
+			got[i] = e.GetContext(ctx, "WORKER", "")
+		}(i)
+	}
+	wg.Wait()
+	for i, v := range got {
+		r.Equal(fmt.Sprint(i), v)
+	}
+}
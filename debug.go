@@ -0,0 +1,67 @@
+package envy
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// DebugDump writes a report of the Env, for support bundles and
+// diagnostics: every key, sorted, with its redacted value, where it
+// came from, whether it's set or the code reading it fell back to a
+// default, and how many times it was read. Denied keys are left out.
+//
+//	KEY        VALUE             SOURCE  STATUS     READS
+//	API_TOKEN  "***REDACTED***"  set     set        0
+//	HOST       -                 -       defaulted  2
+//	PORT       "3000"            .env    set        1
+func (e *Env) DebugDump(w io.Writer) error {
+	m := e.RedactedMap()
+
+	e.moot.RLock()
+	sources := make(map[string]string, len(e.sources))
+	for k, v := range e.sources {
+		sources[k] = v
+	}
+	origin := "initial"
+	if e.osenv {
+		origin = "os"
+	}
+	e.moot.RUnlock()
+
+	e.usageMoot.Lock()
+	counts := make(map[string]readCount, len(e.reads))
+	for k, v := range e.reads {
+		counts[k] = v
+	}
+	e.usageMoot.Unlock()
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	for k := range counts {
+		if _, ok := m[k]; !ok && !e.IsDenied(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE\tSTATUS\tREADS")
+	for _, k := range keys {
+		c := counts[k]
+		v, ok := m[k]
+		if !ok {
+			fmt.Fprintf(tw, "%s\t-\t-\tdefaulted\t%d\n", k, c.hits+c.misses)
+			continue
+		}
+		src, ok := sources[k]
+		if !ok {
+			src = origin
+		}
+		fmt.Fprintf(tw, "%s\t%q\t%s\tset\t%d\n", k, v, src, c.hits+c.misses)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,34 @@
+package envy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_DebugDump(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"APP_NAME": "coke"})
+	r.NoError(e.Load("test_env/.env"))
+	e.Set("API_TOKEN", "abc")
+	e.Set("LD_PRELOAD", "evil.so")
+	r.NoError(e.Deny("LD_*"))
+
+	e.Get("DIR", "")
+	e.Get("HOST", "localhost")
+	e.Get("HOST", "localhost")
+	e.Get("LD_AUDIT", "")
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.DebugDump(bb))
+	r.Equal(`KEY            VALUE             SOURCE         STATUS     READS
+API_TOKEN      "***REDACTED***"  set            set        0
+APP_NAME       "coke"            initial        set        0
+DIR            "test_env"        test_env/.env  set        1
+FLAVOUR        "none"            test_env/.env  set        0
+HOST           -                 -              defaulted  2
+INSIDE_FOLDER  "true"            test_env/.env  set        0
+`, bb.String())
+}
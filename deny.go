@@ -0,0 +1,52 @@
+package envy
+
+import (
+	"fmt"
+	"path"
+)
+
+// Deny excludes the keys matching the given glob patterns, see
+// path.Match, from everything the Env exports: Map, Environ, their
+// redacted variants, ExportEnviron, and the serializers, whatever their
+// options. The keys can still be read with Get, so dangerous
+// variables, such as AWS_SESSION_TOKEN, are usable by the process
+// without ever being propagated to child processes.
+func (e *Env) Deny(patterns ...string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+	}
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.denied = append(e.denied, patterns...)
+	return nil
+}
+
+// Deny excludes the keys matching the given glob
+// patterns from everything envy exports.
+func Deny(patterns ...string) error {
+	return env.Deny(patterns...)
+}
+
+// IsDenied reports whether key is excluded from the exports.
+func (e *Env) IsDenied(key string) bool {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	return matchAny(e.denied, key)
+}
+
+// exported returns a copy of the variables, without the denied ones.
+// It must be called with e.moot held.
+func (e *Env) exported() map[string]string {
+	m := e.all()
+	if len(e.denied) == 0 {
+		return m
+	}
+	for k := range m {
+		if matchAny(e.denied, k) {
+			delete(m, k)
+		}
+	}
+	return m
+}
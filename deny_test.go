@@ -0,0 +1,35 @@
+package envy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Deny(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"AWS_SESSION_TOKEN": "session",
+		"AWS_REGION":        "us-east-1",
+		"LD_PRELOAD":        "evil.so",
+	})
+	r.NoError(e.Deny("AWS_SESSION_*", "LD_PRELOAD"))
+	r.True(e.IsDenied("LD_PRELOAD"))
+	r.False(e.IsDenied("AWS_REGION"))
+
+	r.Equal("session", e.Get("AWS_SESSION_TOKEN", ""))
+
+	r.Equal(map[string]string{"AWS_REGION": "us-east-1"}, e.Map())
+	r.Equal([]string{"AWS_REGION=us-east-1"}, e.Environ())
+	r.Equal(map[string]string{"AWS_REGION": "us-east-1"}, e.RedactedMap())
+	r.Equal([]string{"AWS_REGION=us-east-1"}, e.CmdEnv([]string{""}, nil))
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.ToShell(bb, ExportOptions{Keys: []string{"AWS_*"}}))
+	r.Equal("export AWS_REGION='us-east-1'\n", bb.String())
+
+	r.False(e.Clone().Map()["LD_PRELOAD"] != "")
+	r.Error(e.Deny("["))
+}
@@ -0,0 +1,80 @@
+package envy
+
+import "fmt"
+
+// DeriveFunc computes the value of a derived key, see Derive.
+type DeriveFunc func(e *Env) (string, error)
+
+// Derive registers fn to compute the value of key when it isn't set,
+// for values assembled from several other keys:
+//
+//	e.Derive("DATABASE_URL", func(e *envy.Env) (string, error) {
+//		host, err := e.MustGet("DB_HOST")
+//		if err != nil {
+//			return "", err
+//		}
+//		return fmt.Sprintf("postgres://%s:%s", host, e.Get("DB_PORT", "5432")), nil
+//	})
+//
+// fn is called on the first read of key, with Get, MustGet, Lookup,
+// and the functions built on them, and its value is kept until the
+// Env changes, whether a key is set or unset, it's reloaded, or it
+// loads files. When fn returns an error key is treated as missing,
+// MustGet returning the error, and fn is called again on the next
+// read. fn must not read key itself.
+//
+// Derived values are not set, so they're not returned by Map or
+// Environ. Deriving a key again replaces its DeriveFunc.
+func (e *Env) Derive(key string, fn DeriveFunc) {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	if e.derived == nil {
+		e.derived = map[string]DeriveFunc{}
+	}
+	e.derived[key] = fn
+	e.invalidateDerived()
+}
+
+// Derive registers fn to compute the value of key in envy.
+func Derive(key string, fn DeriveFunc) {
+	env.Derive(key, fn)
+}
+
+// derive returns the value of the derived key, computing it when it
+// isn't cached. It must be called without e.moot held, as fn reads
+// the Env.
+func (e *Env) derive(key string) (string, bool, error) {
+	e.moot.RLock()
+	fn, ok := e.derived[key]
+	v, cached := e.derivedVals[key]
+	gen := e.derivedGen
+	e.moot.RUnlock()
+	if !ok {
+		return "", false, nil
+	}
+	if cached {
+		return v, true, nil
+	}
+
+	v, err := fn(e)
+	if err != nil {
+		return "", false, fmt.Errorf("%s: %w", key, err)
+	}
+	e.moot.Lock()
+	// don't cache a value computed from values replaced since
+	if gen == e.derivedGen {
+		if e.derivedVals == nil {
+			e.derivedVals = map[string]string{}
+		}
+		e.derivedVals[key] = v
+	}
+	e.moot.Unlock()
+	return v, true, nil
+}
+
+// invalidateDerived drops the cached derived values. It must be
+// called with e.moot held.
+func (e *Env) invalidateDerived() {
+	e.derivedGen++
+	e.derivedVals = nil
+}
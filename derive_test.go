@@ -0,0 +1,79 @@
+package envy
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Derive(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"DB_HOST": "db", "DB_PORT": "5432"})
+	calls := 0
+	e.Derive("DATABASE_URL", func(e *Env) (string, error) {
+		calls++
+		host, err := e.MustGet("DB_HOST")
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("postgres://%s:%s", host, e.Get("DB_PORT", "")), nil
+	})
+	r.Equal(0, calls)
+
+	r.Equal("postgres://db:5432", e.Get("DATABASE_URL", ""))
+	v, err := e.MustGet("DATABASE_URL")
+	r.NoError(err)
+	r.Equal("postgres://db:5432", v)
+	r.Equal(1, calls)
+	r.NotContains(e.Map(), "DATABASE_URL")
+
+	e.Set("DB_PORT", "6543")
+	r.Equal("postgres://db:6543", e.Get("DATABASE_URL", ""))
+	r.Equal(2, calls)
+	e.Reload()
+	r.Equal("postgres://db:6543", e.Get("DATABASE_URL", ""))
+	r.Equal(3, calls)
+
+	r.NoError(e.MustSet("DB_HOST", "primary"))
+	r.Equal("postgres://primary:6543", e.Get("DATABASE_URL", ""))
+	restore := e.Override(map[string]string{"DB_HOST": "replica"})
+	r.Equal("postgres://replica:6543", e.Get("DATABASE_URL", ""))
+	restore()
+	r.Equal("postgres://primary:6543", e.Get("DATABASE_URL", ""))
+	e.Unset("DB_PORT")
+	r.Equal("postgres://primary:", e.Get("DATABASE_URL", ""))
+	e.Set("DB_HOST", "db")
+	e.Set("DB_PORT", "6543")
+
+	e.Set("DATABASE_URL", "postgres://other")
+	r.Equal("postgres://other", e.Get("DATABASE_URL", ""))
+	e.Unset("DATABASE_URL")
+
+	c := e.Clone()
+	c.Unset("DB_HOST")
+	_, err = c.MustGet("DATABASE_URL")
+	r.True(errors.Is(err, ErrKeyNotFound{Key: "DB_HOST"}))
+	_, ok := c.Lookup("DATABASE_URL")
+	r.False(ok)
+	r.Equal("postgres://db:6543", e.Get("DATABASE_URL", ""))
+}
+
+func Test_Env_Derive_Load(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(nil)
+	e.Derive("GREETING", func(e *Env) (string, error) {
+		return "hello " + e.Get("NAME", "world"), nil
+	})
+	r.Equal("hello world", e.Get("GREETING", ""))
+
+	f := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(f, []byte("NAME=gopher\n"), 0644))
+	r.NoError(e.Load(f))
+	r.Equal("hello gopher", e.Get("GREETING", ""))
+}
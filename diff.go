@@ -0,0 +1,59 @@
+package envy
+
+import "sort"
+
+// ChangeOp is the kind of a Change.
+type ChangeOp int
+
+const (
+	// Added keys are only in the new set of variables.
+	Added ChangeOp = iota
+	// Removed keys are only in the old set of variables.
+	Removed
+	// Changed keys are in both, with different values.
+	Changed
+)
+
+func (op ChangeOp) String() string {
+	switch op {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return "unknown"
+}
+
+// Change describes how a key differs between two sets of variables.
+type Change struct {
+	Op  ChangeOp
+	Key string
+	Old string
+	New string
+}
+
+// Diff returns the changes, sorted by key, needed to go from
+// the old set of variables to the new one.
+func Diff(old, new map[string]string) []Change {
+	var changes []Change
+	for k, ov := range old {
+		nv, ok := new[k]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Op: Removed, Key: k, Old: ov})
+		case ov != nv:
+			changes = append(changes, Change{Op: Changed, Key: k, Old: ov, New: nv})
+		}
+	}
+	for k, nv := range new {
+		if _, ok := old[k]; !ok {
+			changes = append(changes, Change{Op: Added, Key: k, New: nv})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
@@ -0,0 +1,23 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Diff(t *testing.T) {
+	r := require.New(t)
+
+	old := map[string]string{"A": "1", "B": "2", "C": "3"}
+	new := map[string]string{"A": "1", "B": "two", "D": "4"}
+
+	r.Equal([]Change{
+		{Op: Changed, Key: "B", Old: "2", New: "two"},
+		{Op: Removed, Key: "C", Old: "3"},
+		{Op: Added, Key: "D", New: "4"},
+	}, Diff(old, new))
+
+	r.Empty(Diff(old, old))
+	r.Equal("removed", Removed.String())
+}
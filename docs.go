@@ -0,0 +1,98 @@
+package envy
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Doc documents an ENV variable, see Describe.
+type Doc struct {
+	Key     string `json:"key"`
+	Doc     string `json:"doc"`
+	Example string `json:"example,omitempty"`
+	// Packages that described the variable, sorted.
+	Packages []string `json:"packages"`
+}
+
+var docs = struct {
+	sync.Mutex
+	m map[string]*Doc
+}{m: map[string]*Doc{}}
+
+// Describe documents an ENV variable the calling package consumes,
+// so an application can generate the table of every variable it, and
+// its dependencies, read, for its README or --help output, from Docs.
+// Libraries usually describe their variables when they're initialized:
+//
+//	func init() {
+//		envy.Describe("DATABASE_URL", "the database to connect to", "postgres://localhost:5432/app")
+//	}
+//
+// When more than one package describes the same key, the first
+// non-empty doc and example are kept.
+func Describe(key, doc, example string) {
+	pkg := callerPackage()
+	docs.Lock()
+	defer docs.Unlock()
+	d, ok := docs.m[key]
+	if !ok {
+		d = &Doc{Key: key}
+		docs.m[key] = d
+	}
+	if d.Doc == "" {
+		d.Doc = doc
+	}
+	if d.Example == "" {
+		d.Example = example
+	}
+	i := sort.SearchStrings(d.Packages, pkg)
+	if i == len(d.Packages) || d.Packages[i] != pkg {
+		d.Packages = append(d.Packages, "")
+		copy(d.Packages[i+1:], d.Packages[i:])
+		d.Packages[i] = pkg
+	}
+}
+
+// Docs returns the documentation of every ENV variable
+// described with Describe, sorted by key.
+func Docs() []Doc {
+	docs.Lock()
+	defer docs.Unlock()
+	ds := make([]Doc, 0, len(docs.m))
+	for _, d := range docs.m {
+		c := *d
+		c.Packages = append([]string{}, d.Packages...)
+		ds = append(ds, c)
+	}
+	sort.Slice(ds, func(i, j int) bool {
+		return ds[i].Key < ds[j].Key
+	})
+	return ds
+}
+
+// callerPackage returns the import path of
+// the package calling the caller of callerPackage.
+func callerPackage() string {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+	return funcPackage(fn.Name())
+}
+
+// funcPackage returns the import path of the package of
+// the function name, such as github.com/a/b.(*T).M. The dots in
+// the last element of the path are escaped in the name, as %2e.
+func funcPackage(name string) string {
+	i := strings.LastIndex(name, "/") + 1
+	if j := strings.Index(name[i:], "."); j != -1 {
+		name = name[:i+j]
+	}
+	return strings.ReplaceAll(name, "%2e", ".")
+}
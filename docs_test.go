@@ -0,0 +1,35 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Describe(t *testing.T) {
+	r := require.New(t)
+
+	Describe("ENVY_DOCS_TEST_URL", "the database to connect to", "")
+	Describe("ENVY_DOCS_TEST_URL", "ignored", "postgres://localhost")
+	Describe("ENVY_DOCS_TEST_PORT", "the port to listen on", "3000")
+
+	var got []Doc
+	for _, d := range Docs() {
+		if d.Key == "ENVY_DOCS_TEST_URL" || d.Key == "ENVY_DOCS_TEST_PORT" {
+			got = append(got, d)
+		}
+	}
+	r.Equal([]Doc{
+		{Key: "ENVY_DOCS_TEST_PORT", Doc: "the port to listen on", Example: "3000", Packages: []string{"github.com/gobuffalo/envy"}},
+		{Key: "ENVY_DOCS_TEST_URL", Doc: "the database to connect to", Example: "postgres://localhost", Packages: []string{"github.com/gobuffalo/envy"}},
+	}, got)
+}
+
+func Test_funcPackage(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal("github.com/a/b", funcPackage("github.com/a/b.init.0"))
+	r.Equal("github.com/a/b", funcPackage("github.com/a/b.(*T).M"))
+	r.Equal("gopkg.in/c.v2", funcPackage("gopkg.in/c%2ev2.F"))
+	r.Equal("main", funcPackage("main.main"))
+}
@@ -0,0 +1,47 @@
+package envy
+
+// EmptyPolicy controls how an Env treats the keys set to an empty
+// value, such as KEY= in a .env file, a frequent misconfiguration.
+type EmptyPolicy int
+
+const (
+	// EmptyIsValue treats an empty value like any other.
+	// It's the default.
+	EmptyIsValue EmptyPolicy = iota
+	// EmptyIsUnset treats the keys set to an empty value as unset,
+	// so Get returns the default value, and MustGet an error.
+	EmptyIsUnset
+	// EmptyIsError is like EmptyIsUnset, and Validate also
+	// reports the required keys of a Schema set to an empty
+	// value as errors.
+	EmptyIsError
+)
+
+// WithEmptyPolicy sets how the Env treats empty values.
+func WithEmptyPolicy(p EmptyPolicy) Option {
+	return func(e *Env) {
+		e.empty = p
+	}
+}
+
+// Validate the variables of the Env against s, see Schema.Validate,
+// applying the EmptyPolicy of the Env: with EmptyIsUnset, the keys
+// set to an empty value are validated as if they weren't set, and
+// with EmptyIsError as well, except for the required ones, which are
+// reported as errors.
+func (e *Env) Validate(s Schema) []error {
+	vars := e.Map()
+	if e.empty != EmptyIsValue {
+		for k, v := range vars {
+			if v == "" && (e.empty == EmptyIsUnset || !s[k].Required) {
+				delete(vars, k)
+			}
+		}
+	}
+	return s.validate(vars, e.empty == EmptyIsError)
+}
+
+// Validate the variables of envy against s.
+func Validate(s Schema) []error {
+	return env.Validate(s)
+}
@@ -0,0 +1,76 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithEmptyPolicy(t *testing.T) {
+	r := require.New(t)
+
+	vars := map[string]string{"PORT": "", "HOST": "localhost"}
+
+	e := FromMap(vars)
+	v, ok := e.Lookup("PORT")
+	r.True(ok)
+	r.Equal("", v)
+
+	e = New(WithOSEnv(false), WithEmptyPolicy(EmptyIsUnset))
+	e.Set("PORT", "")
+	e.Set("DB_URL", "postgres://")
+	e.Set("DATABASE_URL", "")
+	r.Equal("3000", e.Get("PORT", "3000"))
+	_, err := e.MustGet("PORT")
+	r.Error(err)
+
+	e.Alias("DATABASE_URL", "DB_URL")
+	r.Equal("postgres://", e.Get("DATABASE_URL", ""))
+
+	var c struct {
+		Port int `envy:"PORT" default:"3000"`
+	}
+	r.NoError(e.Unmarshal(&c))
+	r.Equal(3000, c.Port)
+
+	r.Equal(EmptyIsUnset, e.Clone().empty)
+}
+
+func Test_Env_Validate(t *testing.T) {
+	r := require.New(t)
+
+	s := Schema{
+		"PORT":  {Required: true, Type: "int"},
+		"DEBUG": {Type: "bool"},
+	}
+	vars := map[string]string{"PORT": "", "DEBUG": ""}
+
+	e := New(WithOSEnv(false))
+	for k, v := range vars {
+		e.Set(k, v)
+	}
+	errs := e.Validate(s)
+	r.Len(errs, 2)
+	r.EqualError(errs[0], "DEBUG: not a valid bool")
+	r.EqualError(errs[1], "PORT: not a valid int")
+
+	e = New(WithOSEnv(false), WithEmptyPolicy(EmptyIsUnset))
+	for k, v := range vars {
+		e.Set(k, v)
+	}
+	errs = e.Validate(s)
+	r.Len(errs, 1)
+	r.EqualError(errs[0], "PORT: required but not set")
+
+	e = New(WithOSEnv(false), WithEmptyPolicy(EmptyIsError))
+	for k, v := range vars {
+		e.Set(k, v)
+	}
+	// DEBUG isn't required, so it's unset, like with EmptyIsUnset
+	errs = e.Validate(s)
+	r.Len(errs, 1)
+	r.EqualError(errs[0], "PORT: required but empty")
+
+	e.Set("PORT", "3000")
+	r.Empty(e.Validate(s))
+}
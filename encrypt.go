@@ -0,0 +1,215 @@
+package envy
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// EncryptedPrefix starts the values encrypted by EncryptValue.
+const EncryptedPrefix = "enc:v1:"
+
+// KeySize is the size, in bytes, of the keys used to encrypt values.
+const KeySize = 32
+
+// GenerateKey returns a new random key for EncryptValue.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ReadKeyFile reads a hex encoded key, such as one written by
+// the envy encrypt command, from the file at path.
+func ReadKeyFile(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil || len(key) != KeySize {
+		return nil, fmt.Errorf("%s does not contain a %d byte hex encoded key", path, KeySize)
+	}
+	return key, nil
+}
+
+// EncryptValue encrypts value with key, using AES-GCM. The result
+// starts with EncryptedPrefix and can be stored in .env files.
+func EncryptValue(key []byte, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	b := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return EncryptedPrefix + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// DecryptValue decrypts a value encrypted by EncryptValue.
+// Values that aren't encrypted are returned unchanged.
+func DecryptValue(key []byte, value string) (string, error) {
+	if !strings.HasPrefix(value, EncryptedPrefix) {
+		return value, nil
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, EncryptedPrefix))
+	if err != nil || len(b) < gcm.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+	n := gcm.NonceSize()
+	p, err := gcm.Open(nil, b[:n], b[n:], nil)
+	if err != nil {
+		return "", errors.New("could not decrypt value, wrong key?")
+	}
+	return string(p), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes", KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Decrypt all of the encrypted values in the Env with key.
+func (e *Env) Decrypt(key []byte) error {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	for k, v := range e.all() {
+		d, err := DecryptValue(key, v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		e.store(k, d)
+	}
+	return nil
+}
+
+// EncryptFile encrypts, in place, the values of the .env file at
+// path that aren't encrypted yet, preserving its comments and layout.
+// The values referencing other variables are left as they are, as
+// encrypting them would keep the references from being expanded.
+func EncryptFile(path string, key []byte) error {
+	return transformFile(path, func(v string) (string, error) {
+		if strings.HasPrefix(v, EncryptedPrefix) {
+			return v, nil
+		}
+		return EncryptValue(key, v)
+	})
+}
+
+// DecryptFile decrypts, in place, the encrypted values of the .env
+// file at path, preserving its comments and layout.
+func DecryptFile(path string, key []byte) error {
+	return transformFile(path, func(v string) (string, error) {
+		return DecryptValue(key, v)
+	})
+}
+
+// transformFile rewrites the values of the .env file at path with fn,
+// as they're written in the file, without expanding the references,
+// only touching the values fn changes, and leaving the lines with
+// references alone.
+func transformFile(path string, fn func(v string) (string, error)) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(b), "\n")
+	for i, line := range lines {
+		k, v, err := ParseLine(line, nil)
+		if err != nil {
+			return newErrParse(path, i+1, line, err)
+		}
+		if k == "" {
+			continue
+		}
+		start, end := valueSpan(line)
+		if hasRefs(line[start:end]) {
+			continue
+		}
+		x, err := fn(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		if x != v {
+			lines[i] = line[:start] + formatValue(x) + line[end:]
+		}
+	}
+	return writeFile(path, []byte(strings.Join(lines, "\n")), fi.Mode())
+}
+
+// valueSpan returns where the raw value of line, which ParseLine
+// parses, starts and ends, quotes included, and comment excluded.
+func valueSpan(line string) (int, int) {
+	start := strings.IndexAny(line, "=:") + 1
+	start += leadingSpace(line[start:])
+	raw := line[start:]
+	if raw == "" {
+		return start, start
+	}
+	switch raw[0] {
+	case '\'':
+		return start, start + strings.IndexByte(raw[1:], '\'') + 2
+	case '"':
+		for i := 1; i < len(raw); i++ {
+			switch raw[i] {
+			case '\\':
+				i++
+			case '"':
+				return start, start + i + 1
+			}
+		}
+	case '#':
+		return start, start
+	}
+	for _, c := range []string{" #", "\t#"} {
+		if i := strings.Index(raw, c); i != -1 {
+			raw = raw[:i]
+		}
+	}
+	return start, start + len(strings.TrimRightFunc(raw, unicode.IsSpace))
+}
+
+// hasRefs reports whether the raw value references other variables.
+func hasRefs(raw string) bool {
+	if strings.HasPrefix(raw, "'") {
+		return false
+	}
+	for i := 0; i < len(raw); i++ {
+		switch {
+		case raw[i] == '\\':
+			i++
+		case raw[i] == '$':
+			if _, n := expandRef(raw[i:], nil); n > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
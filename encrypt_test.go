@@ -0,0 +1,113 @@
+package envy
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EncryptValue(t *testing.T) {
+	r := require.New(t)
+
+	key, err := GenerateKey()
+	r.NoError(err)
+
+	enc, err := EncryptValue(key, "hunter2")
+	r.NoError(err)
+	r.True(strings.HasPrefix(enc, EncryptedPrefix))
+	r.NotContains(enc, "hunter2")
+
+	dec, err := DecryptValue(key, enc)
+	r.NoError(err)
+	r.Equal("hunter2", dec)
+
+	dec, err = DecryptValue(key, "plain")
+	r.NoError(err)
+	r.Equal("plain", dec)
+
+	other, err := GenerateKey()
+	r.NoError(err)
+	_, err = DecryptValue(other, enc)
+	r.Error(err)
+
+	_, err = EncryptValue([]byte("short"), "x")
+	r.Error(err)
+}
+
+func Test_EncryptFile(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	key, err := GenerateKey()
+	r.NoError(err)
+	kf := filepath.Join(dir, "key.txt")
+	r.NoError(ioutil.WriteFile(kf, []byte(hex.EncodeToString(key)+"\n"), 0600))
+
+	rk, err := ReadKeyFile(kf)
+	r.NoError(err)
+	r.Equal(key, rk)
+
+	path := filepath.Join(dir, ".env")
+	r.NoError(ioutil.WriteFile(path, []byte("# secrets\nTOKEN=hunter2\nNAME='envy app'\n"), 0600))
+
+	r.NoError(EncryptFile(path, key))
+	b, err := ioutil.ReadFile(path)
+	r.NoError(err)
+	r.Contains(string(b), "# secrets\nTOKEN="+EncryptedPrefix)
+	r.NotContains(string(b), "hunter2")
+
+	// already encrypted values are left alone
+	r.NoError(EncryptFile(path, key))
+
+	e := New(WithOSEnv(false))
+	r.NoError(e.Load(path))
+	r.NoError(e.Decrypt(key))
+	r.Equal("hunter2", e.Get("TOKEN", ""))
+	r.Equal("envy app", e.Get("NAME", ""))
+
+	r.NoError(DecryptFile(path, key))
+	b, err = ioutil.ReadFile(path)
+	r.NoError(err)
+	r.Equal("# secrets\nTOKEN=hunter2\nNAME=\"envy app\"\n", string(b))
+}
+
+func Test_EncryptFile_RoundTrip(t *testing.T) {
+	r := require.New(t)
+
+	key, err := GenerateKey()
+	r.NoError(err)
+
+	const in = `# secrets
+export TOKEN=hunter2 # rotate monthly
+HOST=localhost
+URL=http://${HOST}/
+PASSWORD: "p@ss word"
+EMPTY=
+`
+	path := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(path, []byte(in), 0600))
+
+	r.NoError(EncryptFile(path, key))
+	b, err := ioutil.ReadFile(path)
+	r.NoError(err)
+	r.Contains(string(b), "export TOKEN="+EncryptedPrefix)
+	r.Contains(string(b), " # rotate monthly\n")
+	r.Contains(string(b), "\nURL=http://${HOST}/\n")
+	r.NotContains(string(b), "hunter2")
+	r.NotContains(string(b), "p@ss word")
+
+	e := New(WithOSEnv(false))
+	r.NoError(e.Load(path))
+	r.NoError(e.Decrypt(key))
+	r.Equal("hunter2", e.Get("TOKEN", ""))
+	r.Equal("p@ss word", e.Get("PASSWORD", ""))
+
+	r.NoError(DecryptFile(path, key))
+	b, err = ioutil.ReadFile(path)
+	r.NoError(err)
+	r.Equal(in, string(b))
+}
@@ -0,0 +1,616 @@
+package envy
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Env holds a set of ENV variables. The package level functions,
+// such as Get and Set, operate on a default Env that is loaded
+// from the OS when envy is initialized.
+type Env struct {
+	moot           *sync.RWMutex
+	vars           map[string]string
+	osenv          bool
+	wipe           bool
+	encrypt        bool
+	sealed         map[string][]byte
+	recorders      []*Recorder
+	accessHooks    []AccessHook
+	rotateHooks    []rotateHook
+	setHooks       []SetHook
+	changeHooks    []func(Change)
+	metrics        Metrics
+	logger         Logger
+	sources        map[string]string
+	usageMoot      *sync.Mutex
+	reads          map[string]readCount
+	statuses       map[string]SourceStatus
+	loader         Loader
+	tool           *goTool
+	gotool         bool
+	fold           bool
+	aliases        map[string][]string
+	normalize      func(string) string
+	delimiter      string
+	registry       bool
+	settings       []Settings
+	settingVars    map[string]string
+	relaxedKeys    bool
+	noAutoLoad     bool
+	empty          EmptyPolicy
+	scrub          ScrubPolicy
+	profileValues  bool
+	buildTags      []string
+	derived        map[string]DeriveFunc
+	derivedVals    map[string]string
+	derivedGen     uint64
+	ttls           map[string]*ttlValue
+	limits         Limits
+	limitHooks     []func(error)
+	historySize    int
+	history        []HistoryEntry
+	runtimeVars    bool
+	denied         []string
+	secrets        map[string]bool
+	secretPatterns []string
+}
+
+func newEnv(vars map[string]string) *Env {
+	return &Env{
+		moot:           &sync.RWMutex{},
+		vars:           vars,
+		sealed:         map[string][]byte{},
+		metrics:        NopMetrics{},
+		logger:         nopLogger{},
+		sources:        map[string]string{},
+		settingVars:    map[string]string{},
+		usageMoot:      &sync.Mutex{},
+		reads:          map[string]readCount{},
+		statuses:       map[string]SourceStatus{},
+		loader:         GodotenvLoader{},
+		tool:           &goTool{},
+		gotool:         true,
+		secrets:        map[string]bool{},
+		secretPatterns: append([]string{}, DefaultSecretPatterns...),
+	}
+}
+
+// New returns an Env configured by the given options.
+// By default it's loaded from the OS environment.
+func New(opts ...Option) *Env {
+	e := newEnv(map[string]string{})
+	e.osenv = true
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.noAutoLoad {
+		e.osenv = false
+		e.vars = ParseEnviron(os.Environ())
+		// undo the values of ./.env, unless they were changed since
+		for k, v := range autoLoaded {
+			if e.vars[k] != v {
+				continue
+			}
+			if sv, ok := startEnviron[k]; ok {
+				e.vars[k] = sv
+			} else {
+				delete(e.vars, k)
+			}
+		}
+		e.seal()
+	}
+	if e.osenv {
+		if err := e.loadEnv(); err != nil {
+			e.logger.Warn("envy: could not determine GOPATH", "error", err)
+		}
+	}
+	// the registry is part of the environment, which the
+	// settings only fill the gaps of
+	if e.registry && runtime.GOOS == "windows" {
+		if err := e.LoadRegistry(); err != nil {
+			e.logger.Warn("envy: could not load the registry", "error", err)
+		}
+	}
+	e.seedSettings()
+	return e
+}
+
+// FromMap returns an Env holding only the given pairs. It doesn't
+// read the OS environment, load .env files, or run the go binary,
+// and, like an Env returned by Clone, it isn't bound to the OS.
+func FromMap(m map[string]string) *Env {
+	return newEnv(copyVars(m))
+}
+
+// Load the ENV variables from the OS into the env map
+func (e *Env) loadEnv() error {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	return e.readOS()
+}
+
+// readOS reads the OS environment into e.vars, returning the
+// error, if any, of setting the GOPATH; the OS environment is
+// read regardless. It must be called with e.moot held, so the
+// go tool is run with the OS environment rather than e.Environ.
+func (e *Env) readOS() error {
+	var gperr error
+	if os.Getenv("GO_ENV") == "" {
+		// if the flag "test.v" is *defined*, we're running as a unit test. Note that we don't care
+		// about v.Value (verbose test mode); we just want to know if the test environment has defined
+		// it. It's also possible that the flags are not yet fully parsed (i.e. flag.Parsed() == false),
+		// so we could not depend on v.Value anyway.
+		//
+		if v := flag.Lookup("test.v"); v != nil {
+			e.vars["GO_ENV"] = "test"
+		}
+	}
+
+	// set the GOPATH if using >= 1.8 and the GOPATH isn't set
+	if os.Getenv("GOPATH") == "" && e.gotool {
+		bin := os.Getenv("GO_BIN")
+		if bin == "" {
+			bin = "go"
+		}
+		m, err := e.tool.loadEnv(bin, func() *exec.Cmd {
+			return exec.Command(bin, "env", "-json")
+		})
+		if err == nil {
+			os.Setenv("GOPATH", m["GOPATH"])
+		}
+		gperr = err
+	}
+
+	for k, v := range ParseEnviron(os.Environ()) {
+		e.vars[k] = v
+	}
+	e.seal()
+	return gperr
+}
+
+// Reload the ENV variables from the OS. Useful if an external
+// ENV manager has been used. On an Env that isn't bound to the OS,
+// such as one returned by Clone, Reload only drops the cached
+// derived values, see Derive.
+func (e *Env) Reload() {
+	if !e.osenv {
+		e.moot.Lock()
+		e.invalidateDerived()
+		e.moot.Unlock()
+		return
+	}
+	start := time.Now()
+	e.moot.Lock()
+	old := e.all()
+	wipeAll(e.sealed)
+	e.sealed = map[string][]byte{}
+	e.vars = map[string]string{}
+	err := e.readOS()
+	e.applySettings()
+	calls := e.changed(old, e.all())
+	n := len(e.vars) + len(e.sealed)
+	m, l := e.metrics, e.logger
+	e.statuses["os"] = newSourceStatus("os", err)
+	e.moot.Unlock()
+
+	d := time.Since(start)
+	m.Refreshed("os", d, err)
+	if err != nil {
+		l.Warn("envy: could not determine GOPATH", "error", err)
+	}
+	l.Debug("envy: reloaded", "source", "os", "keys", n, "duration", d)
+	runAll(calls)
+}
+
+// Load .env files. Files will be loaded in the same order that are received.
+// Redefined vars will override previously existing values.
+// If no arg passed, it will try to load a .env file.
+//
+// An Env bound to the OS writes the loaded values into the OS
+// environment, just like the package level Load. Any other Env
+// only keeps the loaded values for itself. A file with an invalid
+// key, see ValidKey and WithRelaxedKeys, isn't loaded at all.
+func (e *Env) Load(files ...string) error {
+	return e.LoadContext(context.Background(), files...)
+}
+
+// LoadContext is like Load, but gives up, returning the error of ctx,
+// when ctx is done before the files are loaded, so a slow Loader, such
+// as one fetching the values from a secrets backend, can't block the
+// startup of the process indefinitely. Loaders implementing
+// ContextLoader are handed ctx; the others are abandoned.
+func (e *Env) LoadContext(ctx context.Context, files ...string) error {
+	return e.LoadWith(ctx, LoadOptions{}, files...)
+}
+
+// LoadOptions control how LoadWith loads files.
+type LoadOptions struct {
+	// ContinueOnError attempts to load every file, rather than
+	// stopping at the first one that fails, and returns the errors
+	// of all of those that failed, joined like errors.Join does.
+	ContinueOnError bool
+	// IgnoreMissing skips the files that don't exist, such as
+	// optional local override files.
+	IgnoreMissing bool
+}
+
+// LoadWith is like LoadContext, with the given options:
+//
+//	err := e.LoadWith(ctx, envy.LoadOptions{IgnoreMissing: true}, ".env", ".env.local")
+func (e *Env) LoadWith(ctx context.Context, opts LoadOptions, files ...string) (err error) {
+	defer func(start time.Time) {
+		d := time.Since(start)
+		e.getMetrics().Loaded(d, err)
+		if err != nil {
+			e.getLogger().Warn("envy: could not load", "files", files, "error", err)
+			return
+		}
+		e.getLogger().Info("envy: loaded", "files", files, "duration", d)
+	}(time.Now())
+
+	// If no files received, load the default one
+	if len(files) == 0 {
+		err := e.load(ctx, ".env")
+		if opts.IgnoreMissing && errors.Is(err, ErrFileNotFound{}) {
+			return nil
+		}
+		return err
+	}
+
+	// We received a list of files
+	var errs []error
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return joinErrors(append(errs, err)...)
+		}
+
+		err := e.loadFile(ctx, file)
+		switch {
+		case err == nil:
+		case opts.IgnoreMissing && errors.Is(err, ErrFileNotFound{}):
+		case !opts.ContinueOnError:
+			return err
+		default:
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs...)
+}
+
+// loadFile loads file, once it's known to exist.
+func (e *Env) loadFile(ctx context.Context, file string) error {
+	// Check if it exists or we can access
+	if _, err := os.Stat(file); err != nil {
+		// It does not exist or we can not access.
+		if os.IsNotExist(err) {
+			err = ErrFileNotFound{Path: file, Err: err}
+		}
+		e.setStatus(file, err)
+		return err
+	}
+
+	// It exists and we have permission. Load it
+	return e.load(ctx, file)
+}
+
+func (e *Env) load(ctx context.Context, file string) (err error) {
+	defer func() {
+		e.setStatus(file, err)
+	}()
+
+	m, err := e.read(ctx, file)
+	if os.IsNotExist(err) {
+		return ErrFileNotFound{Path: file, Err: err}
+	}
+	if err != nil {
+		return withSource(file, err)
+	}
+	if e.profileValues {
+		m = resolveProfileValues(m, e.Profile())
+	}
+	return withSource(file, e.apply(file, m))
+}
+
+// apply the values of m, read from source, to the Env, and to the
+// OS environment for an Env bound to it, once their keys are known
+// to be valid, see WithRelaxedKeys.
+func (e *Env) apply(source string, m map[string]string) error {
+	return e.applyKeys(source, m, e.relaxedKeys)
+}
+
+// applyKeys is apply, checking the keys with ValidRelaxedKey when
+// relaxed, and with ValidKey otherwise.
+func (e *Env) applyKeys(source string, m map[string]string, relaxed bool) error {
+	if err := checkKeys(source, m, relaxed); err != nil {
+		return err
+	}
+	e.moot.Lock()
+	calls, err := e.checkLimits(m)
+	e.moot.Unlock()
+	if err != nil {
+		runAll(calls)
+		return err
+	}
+	if e.osenv {
+		for k, v := range m {
+			if err := os.Setenv(k, v); err != nil {
+				return err
+			}
+		}
+		e.moot.Lock()
+		for k := range m {
+			e.sources[k] = source
+		}
+		e.moot.Unlock()
+		// Reload the env so all new changes are noticed
+		e.Reload()
+		return nil
+	}
+
+	e.moot.Lock()
+	old, loaded := map[string]string{}, map[string]string{}
+	for k, v := range m {
+		k = e.canon(k)
+		if ov, ok := e.get(k); ok {
+			old[k] = ov
+		}
+		e.store(k, v)
+		e.sources[k] = source
+		loaded[k] = v
+	}
+	calls = e.changed(old, loaded)
+	e.moot.Unlock()
+	runAll(calls)
+	return nil
+}
+
+// Get a value from the ENV. If it doesn't exist the
+// default value will be returned.
+func (e *Env) Get(key string, value string) string {
+	if v, ok := e.lookup(key); ok {
+		return v
+	}
+	return value
+}
+
+// MustGet a value from the ENV. If it doesn't exist
+// an ErrKeyNotFound will be returned
+func (e *Env) MustGet(key string) (string, error) {
+	v, ok, err := e.lookupErr(key)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return v, nil
+	}
+	return "", ErrKeyNotFound{Key: key}
+}
+
+// Lookup a value from the ENV. Like os.LookupEnv, the boolean
+// reports whether the key was found.
+func (e *Env) Lookup(key string) (string, bool) {
+	return e.lookup(key)
+}
+
+// lookup is where all of the reads of a single key end up.
+func (e *Env) lookup(key string) (string, bool) {
+	v, ok, _ := e.lookupErr(key)
+	return v, ok
+}
+
+// lookupErr is like lookup, also returning the error
+// of the DeriveFunc of key, see Derive.
+func (e *Env) lookupErr(key string) (string, bool, error) {
+	e.moot.RLock()
+	name := key
+	v, ok := e.resolve(key)
+	if !ok && e.normalize != nil {
+		if n := e.normalize(key); n != key {
+			key = n
+			v, ok = e.resolve(key)
+		}
+	}
+	e.moot.RUnlock()
+	var err error
+	if !ok {
+		v, ok, err = e.derive(name)
+	}
+	if !ok && err == nil {
+		v, ok = e.runtimeVar(name)
+	}
+	e.access(key, ok)
+	return v, ok, err
+}
+
+// Set a value into the Env. This is NOT permanent. It will
+// only affect values accessed through this Env. A value that would
+// exceed the Limits of the Env is rejected, see WithLimits.
+func (e *Env) Set(key string, value string) {
+	e.moot.Lock()
+	calls, err := e.checkLimits(map[string]string{key: value})
+	if err == nil {
+		calls = e.set(key, value)
+	}
+	e.moot.Unlock()
+	runAll(calls)
+}
+
+// MustSet the value into the underlying ENV, as well as the Env.
+// This may return an error if there is a problem setting the
+// underlying ENV value. An Env that isn't bound to the OS
+// behaves like Set.
+//
+// The value is first passed through the hooks registered with
+// OnSet, which may transform, or reject, it.
+func (e *Env) MustSet(key string, value string) error {
+	value, err := e.runSetHooks(key, value)
+	if err != nil {
+		return err
+	}
+
+	e.moot.Lock()
+	key = e.canon(key)
+	if calls, err := e.checkLimits(map[string]string{key: value}); err != nil {
+		e.moot.Unlock()
+		runAll(calls)
+		return err
+	}
+	if e.osenv {
+		if err := os.Setenv(key, value); err != nil {
+			e.moot.Unlock()
+			return err
+		}
+	}
+	calls := e.set(key, value)
+	e.moot.Unlock()
+	runAll(calls)
+	return nil
+}
+
+// set the value of key, returning the hooks to call.
+// It must be called with e.moot held.
+func (e *Env) set(key string, value string) []func() {
+	key = e.canon(key)
+	old := map[string]string{}
+	if v, ok := e.get(key); ok {
+		old[key] = v
+	}
+	e.store(key, value)
+	e.sources[key] = "set"
+	return e.changed(old, map[string]string{key: value})
+}
+
+// Map all of the keys/values set in the Env, except the denied ones.
+func (e *Env) Map() map[string]string {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	return e.exported()
+}
+
+// Environ returns the Env, except the denied keys, as a list of
+// "key=value" strings, in the same format as os.Environ.
+func (e *Env) Environ() []string {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	var x []string
+	for k, v := range e.exported() {
+		x = append(x, fmt.Sprintf("%s=%s", k, v))
+	}
+	return x
+}
+
+// Temp makes a copy of the values and allows operation on
+// those values temporarily during the run of the function.
+// At the end of the function run the copy is discarded and
+// the original values are replaced. This is useful for testing.
+// Calls to Temp may be nested; each one restores the values
+// as they were when it was called.
+// Warning: This function is NOT safe to use from a goroutine or
+// from code which may access any Get or Set function from a goroutine
+func (e *Env) Temp(f func()) {
+	e.TempE(func() error {
+		f()
+		return nil
+	})
+}
+
+// TempE is like Temp, but returns the error returned by f,
+// so setup code and test helpers can propagate failures.
+func (e *Env) TempE(f func() error) error {
+	defer e.save()()
+	return f()
+}
+
+// TB is the part of testing.TB used by TempT.
+type TB interface {
+	Helper()
+	Cleanup(func())
+}
+
+// TempT makes a copy of the values, like Temp, and restores the
+// original values when the test t, usually a *testing.T, and all
+// of its subtests complete. Like Temp, it must not be used from
+// parallel tests.
+func (e *Env) TempT(t TB) {
+	t.Helper()
+	t.Cleanup(e.save())
+}
+
+// save makes a copy of the values, returning
+// a func that restores the original ones.
+func (e *Env) save() func() {
+	e.moot.Lock()
+	vars, sealed := e.vars, e.sealed
+	e.vars, e.sealed = copyVars(vars), copySealed(sealed)
+	e.moot.Unlock()
+
+	return func() {
+		e.moot.Lock()
+		wipeAll(e.sealed)
+		e.vars, e.sealed = vars, sealed
+		e.moot.Unlock()
+	}
+}
+
+// Clone returns a copy of the Env. The copy isn't bound to the
+// OS: MustSet and Load only affect the copy, and Reload has no
+// effect on it.
+func (e *Env) Clone() *Env {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	c := newEnv(e.all())
+	for k := range e.secrets {
+		c.secrets[k] = true
+	}
+	c.secretPatterns = append([]string{}, e.secretPatterns...)
+	c.denied = append([]string{}, e.denied...)
+	for k, v := range e.sources {
+		c.sources[k] = v
+	}
+	c.loader = e.loader
+	c.tool = e.tool
+	c.gotool = e.gotool
+	c.fold = e.fold
+	c.normalize = e.normalize
+	c.delimiter = e.delimiter
+	c.empty = e.empty
+	c.scrub = e.scrub
+	c.relaxedKeys = e.relaxedKeys
+	c.profileValues = e.profileValues
+	if e.aliases != nil {
+		c.aliases = map[string][]string{}
+		for k, v := range e.aliases {
+			c.aliases[k] = v
+		}
+	}
+	c.buildTags = append([]string{}, e.buildTags...)
+	c.limits = e.limits
+	c.historySize = e.historySize
+	c.runtimeVars = e.runtimeVars
+	if e.derived != nil {
+		c.derived = map[string]DeriveFunc{}
+		for k, fn := range e.derived {
+			c.derived[k] = fn
+		}
+	}
+	c.wipe = e.wipe
+	c.encrypt = e.encrypt
+	c.seal()
+	return c
+}
+
+func copyVars(m map[string]string) map[string]string {
+	cp := make(map[string]string, len(m))
+	for k, v := range m {
+		cp[k] = v
+	}
+	return cp
+}
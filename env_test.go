@@ -0,0 +1,94 @@
+package envy
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Clone(t *testing.T) {
+	r := require.New(t)
+
+	e := New()
+	e.Set("ENVY_CLONE", "orig")
+
+	c := e.Clone()
+	r.Equal("orig", c.Get("ENVY_CLONE", ""))
+
+	r.NoError(c.MustSet("ENVY_CLONE", "clone"))
+	r.Equal("clone", c.Get("ENVY_CLONE", ""))
+	r.Equal("orig", e.Get("ENVY_CLONE", ""))
+	r.Zero(os.Getenv("ENVY_CLONE"))
+}
+
+func Test_Env_Clone_Load(t *testing.T) {
+	r := require.New(t)
+
+	c := New().Clone()
+	r.NoError(c.Load("test_env/.env.prod"))
+	r.Equal("production", c.Get("FLAVOUR", ""))
+	r.NotEqual("production", os.Getenv("FLAVOUR"))
+
+	r.Error(c.Load(".env.FAKE"))
+}
+
+func Test_FromMap(t *testing.T) {
+	r := require.New(t)
+
+	m := map[string]string{"FOO": "foo"}
+	e := FromMap(m)
+	r.Equal(map[string]string{"FOO": "foo"}, e.Map())
+	r.Equal("", e.Get("GOPATH", ""))
+
+	m["FOO"] = "bar"
+	r.Equal("foo", e.Get("FOO", ""))
+
+	r.NoError(e.MustSet("ENVY_FROM_MAP", "x"))
+	r.Zero(os.Getenv("ENVY_FROM_MAP"))
+
+	e.Reload()
+	r.Equal("x", e.Get("ENVY_FROM_MAP", ""))
+}
+
+func Test_Env_LoadWith(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	good := filepath.Join(dir, ".env")
+	bad := filepath.Join(dir, ".env.bad")
+	missing := filepath.Join(dir, ".env.local")
+	r.NoError(ioutil.WriteFile(good, []byte("A=1\n"), 0644))
+	r.NoError(ioutil.WriteFile(bad, []byte("B\n"), 0644))
+	later := filepath.Join(dir, ".env.later")
+	r.NoError(ioutil.WriteFile(later, []byte("C=3\n"), 0644))
+
+	e := New(WithOSEnv(false), WithLoader(NativeLoader{}))
+	err := e.LoadWith(context.Background(), LoadOptions{}, good, missing, later)
+	r.True(errors.Is(err, ErrFileNotFound{Path: missing}))
+	_, ok := e.Lookup("C")
+	r.False(ok)
+
+	e = New(WithOSEnv(false), WithLoader(NativeLoader{}))
+	r.NoError(e.LoadWith(context.Background(), LoadOptions{IgnoreMissing: true}, good, missing, later))
+	r.Equal(map[string]string{"A": "1", "C": "3"}, e.Map())
+
+	e = New(WithOSEnv(false), WithLoader(NativeLoader{}))
+	err = e.LoadWith(context.Background(), LoadOptions{ContinueOnError: true}, missing, bad, later)
+	r.Error(err)
+	r.True(errors.Is(err, ErrFileNotFound{Path: missing}))
+	r.True(errors.Is(err, ErrParse{File: bad}))
+	r.Contains(err.Error(), missing)
+	r.Contains(err.Error(), bad+":1:")
+	r.Equal("3", e.Get("C", ""))
+
+	e = New(WithOSEnv(false), WithLoader(LoaderFunc(func(path string) (map[string]string, error) {
+		return nil, errors.New("boom")
+	})))
+	err = e.LoadWith(context.Background(), LoadOptions{ContinueOnError: true}, good, later)
+	r.EqualError(err, good+": boom\n"+later+": boom")
+}
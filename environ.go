@@ -0,0 +1,38 @@
+package envy
+
+import "strings"
+
+// SplitEnvPair splits a "key=value" string, as returned by os.Environ,
+// on its first "=", so values holding "=", such as connection strings,
+// are kept whole. A leading "=" is part of the key, as in the hidden
+// "=C:=C:\work" entries Windows uses for the working directory of each
+// drive. The boolean is false when s holds no pair.
+func SplitEnvPair(s string) (key string, value string, ok bool) {
+	i := strings.IndexByte(s, '=')
+	if i == 0 {
+		i = strings.IndexByte(s[1:], '=')
+		if i != -1 {
+			i++
+		}
+	}
+	if i == -1 {
+		return "", "", false
+	}
+	return s[:i], s[i+1:], true
+}
+
+// ParseEnviron returns the pairs of environ, in the format returned by
+// os.Environ, as a map. Entries without a "=", and the hidden Windows
+// entries starting with "=", are skipped. Later entries take
+// precedence over earlier ones with the same key.
+func ParseEnviron(environ []string) map[string]string {
+	m := make(map[string]string, len(environ))
+	for _, x := range environ {
+		k, v, ok := SplitEnvPair(x)
+		if !ok || strings.HasPrefix(k, "=") {
+			continue
+		}
+		m[k] = v
+	}
+	return m
+}
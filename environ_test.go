@@ -0,0 +1,67 @@
+package envy
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SplitEnvPair(t *testing.T) {
+	r := require.New(t)
+
+	table := []struct {
+		in    string
+		key   string
+		value string
+		ok    bool
+	}{
+		{"A=b", "A", "b", true},
+		{"EMPTY=", "EMPTY", "", true},
+		{"DSN=host=db user=me sslmode=disable", "DSN", "host=db user=me sslmode=disable", true},
+		{"TOKEN=abc==", "TOKEN", "abc==", true},
+		{`=C:=C:\work`, "=C:", `C:\work`, true},
+		{"NOPE", "", "", false},
+		{"=", "", "", false},
+	}
+	for _, tt := range table {
+		k, v, ok := SplitEnvPair(tt.in)
+		r.Equal(tt.ok, ok, tt.in)
+		r.Equal(tt.key, k, tt.in)
+		r.Equal(tt.value, v, tt.in)
+	}
+}
+
+func Test_ParseEnviron(t *testing.T) {
+	r := require.New(t)
+
+	m := ParseEnviron([]string{
+		"A=1",
+		"DSN=host=db port=5432",
+		"EMPTY=",
+		`=C:=C:\work`,
+		"=ExitCode=00000000",
+		"NOPE",
+		"A=2",
+	})
+	r.Equal(map[string]string{
+		"A":     "2",
+		"DSN":   "host=db port=5432",
+		"EMPTY": "",
+	}, m)
+}
+
+func Test_Env_Reload_Equals(t *testing.T) {
+	r := require.New(t)
+
+	r.NoError(os.Setenv("ENVY_TEST_DSN", "host=db password=a=b"))
+	defer os.Unsetenv("ENVY_TEST_DSN")
+	r.NoError(os.Setenv("ENVY_TEST_EMPTY", ""))
+	defer os.Unsetenv("ENVY_TEST_EMPTY")
+
+	e := New()
+	r.Equal("host=db password=a=b", e.Get("ENVY_TEST_DSN", ""))
+	v, ok := e.Lookup("ENVY_TEST_EMPTY")
+	r.True(ok)
+	r.Equal("", v)
+}
@@ -11,67 +11,48 @@ package envy makes working with ENV variables in Go trivial.
 package envy
 
 import (
+	"context"
 	"errors"
-	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
+	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
-	"sync"
 
-	"github.com/joho/godotenv"
 	"github.com/rogpeppe/go-internal/modfile"
 )
 
-var gil = &sync.RWMutex{}
-var env = map[string]string{}
+var env = New()
 
 // GO111MODULE is ENV for turning mods on/off
 const GO111MODULE = "GO111MODULE"
 
+// startEnviron is the OS environment before init loaded ./.env into
+// it, and autoLoaded the values it loaded, see WithAutoLoad.
+var startEnviron, autoLoaded map[string]string
+
 func init() {
+	startEnviron = ParseEnviron(os.Environ())
 	Load()
-	loadEnv()
-}
-
-// Load the ENV variables to the env map
-func loadEnv() {
-	gil.Lock()
-	defer gil.Unlock()
-
-	if os.Getenv("GO_ENV") == "" {
-		// if the flag "test.v" is *defined*, we're running as a unit test. Note that we don't care
-		// about v.Value (verbose test mode); we just want to know if the test environment has defined
-		// it. It's also possible that the flags are not yet fully parsed (i.e. flag.Parsed() == false),
-		// so we could not depend on v.Value anyway.
-		//
-		if v := flag.Lookup("test.v"); v != nil {
-			env["GO_ENV"] = "test"
-		}
-	}
-
-	// set the GOPATH if using >= 1.8 and the GOPATH isn't set
-	if os.Getenv("GOPATH") == "" {
-		out, err := exec.Command("go", "env", "GOPATH").Output()
-		if err == nil {
-			gp := strings.TrimSpace(string(out))
-			os.Setenv("GOPATH", gp)
+	autoLoaded = map[string]string{}
+	for k, v := range ParseEnviron(os.Environ()) {
+		if sv, ok := startEnviron[k]; !ok || sv != v {
+			autoLoaded[k] = v
 		}
 	}
+}
 
-	for _, e := range os.Environ() {
-		pair := strings.Split(e, "=")
-		env[pair[0]] = os.Getenv(pair[0])
-	}
+// Default returns the Env used by the package level functions.
+func Default() *Env {
+	return env
 }
 
 // Reload the ENV variables. Useful if
 // an external ENV manager has been used
 func Reload() {
-	env = map[string]string{}
-	loadEnv()
+	env.Reload()
 }
 
 // Load .env files. Files will be loaded in the same order that are received.
@@ -79,91 +60,66 @@ func Reload() {
 // IE: envy.Load(".env", "test_env/.env") will result in DIR=test_env
 // If no arg passed, it will try to load a .env file.
 func Load(files ...string) error {
+	return env.Load(files...)
+}
 
-	// If no files received, load the default one
-	if len(files) == 0 {
-		err := godotenv.Overload()
-		if err == nil {
-			Reload()
-		}
-		return err
-	}
-
-	// We received a list of files
-	for _, file := range files {
-
-		// Check if it exists or we can access
-		if _, err := os.Stat(file); err != nil {
-			// It does not exist or we can not access.
-			// Return and stop loading
-			return err
-		}
+// LoadContext is like Load, but gives up when ctx is done.
+func LoadContext(ctx context.Context, files ...string) error {
+	return env.LoadContext(ctx, files...)
+}
 
-		// It exists and we have permission. Load it
-		if err := godotenv.Overload(file); err != nil {
-			return err
-		}
+// LoadWith is like LoadContext, with the given options.
+func LoadWith(ctx context.Context, opts LoadOptions, files ...string) error {
+	return env.LoadWith(ctx, opts, files...)
+}
 
-		// Reload the env so all new changes are noticed
-		Reload()
+// LoadProfile loads the .env, .env.<profile>, and .env.<profile>.local
+// files, when they exist, in that order. When profile is empty the
+// active profile, GO_ENV, is used.
+func LoadProfile(profile string) error {
+	return env.LoadProfile(profile)
+}
 
-	}
-	return nil
+// Profile returns the active profile, the value of GO_ENV,
+// defaulting to "development".
+func Profile() string {
+	return env.Profile()
 }
 
 // Get a value from the ENV. If it doesn't exist the
 // default value will be returned.
 func Get(key string, value string) string {
-	gil.RLock()
-	defer gil.RUnlock()
-	if v, ok := env[key]; ok {
-		return v
-	}
-	return value
+	return env.Get(key, value)
 }
 
 // Get a value from the ENV. If it doesn't exist
 // an error will be returned
 func MustGet(key string) (string, error) {
-	gil.RLock()
-	defer gil.RUnlock()
-	if v, ok := env[key]; ok {
-		return v, nil
-	}
-	return "", fmt.Errorf("could not find ENV var with %s", key)
+	return env.MustGet(key)
+}
+
+// Lookup a value from the ENV. Like os.LookupEnv, the
+// boolean reports whether the key was found.
+func Lookup(key string) (string, bool) {
+	return env.Lookup(key)
 }
 
 // Set a value into the ENV. This is NOT permanent. It will
 // only affect values accessed through envy.
 func Set(key string, value string) {
-	gil.Lock()
-	defer gil.Unlock()
-	env[key] = value
+	env.Set(key, value)
 }
 
 // MustSet the value into the underlying ENV, as well as envy.
 // This may return an error if there is a problem setting the
 // underlying ENV value.
 func MustSet(key string, value string) error {
-	gil.Lock()
-	defer gil.Unlock()
-	err := os.Setenv(key, value)
-	if err != nil {
-		return err
-	}
-	env[key] = value
-	return nil
+	return env.MustSet(key, value)
 }
 
 // Map all of the keys/values set in envy.
 func Map() map[string]string {
-	gil.RLock()
-	defer gil.RUnlock()
-	cp := map[string]string{}
-	for k, v := range env {
-		cp[k] = v
-	}
-	return cp
+	return env.Map()
 }
 
 // Temp makes a copy of the values and allows operation on
@@ -173,23 +129,91 @@ func Map() map[string]string {
 // Warning: This function is NOT safe to use from a goroutine or
 // from code which may access any Get or Set function from a goroutine
 func Temp(f func()) {
-	oenv := env
-	env = map[string]string{}
-	for k, v := range oenv {
-		env[k] = v
-	}
-	defer func() { env = oenv }()
-	f()
+	env.Temp(f)
+}
+
+// TempE is like Temp, but returns the error returned by f.
+func TempE(f func() error) error {
+	return env.TempE(f)
+}
+
+// TempT makes a copy of the values and restores the original
+// values when the test t completes.
+func TempT(t TB) {
+	t.Helper()
+	env.TempT(t)
+}
+
+// MarkSecret marks the given keys as secrets, whose values
+// are redacted by RedactedMap and RedactedEnviron.
+func MarkSecret(keys ...string) {
+	env.MarkSecret(keys...)
+}
+
+// IsSecret reports whether key is a secret.
+func IsSecret(key string) bool {
+	return env.IsSecret(key)
+}
+
+// RedactedMap is like Map, but with the values of
+// the secrets replaced by Redacted.
+func RedactedMap() map[string]string {
+	return env.RedactedMap()
 }
 
+// RedactedEnviron is like Environ, but with the values
+// of the secrets replaced by Redacted.
+func RedactedEnviron() []string {
+	return env.RedactedEnviron()
+}
+
+// GoPath returns the GOPATH, or, when it isn't set, the one the
+// go tool uses, see GoEnv.
 func GoPath() string {
-	return Get("GOPATH", "")
+	if gp := Get("GOPATH", ""); gp != "" {
+		return gp
+	}
+	m, _ := env.goEnv()
+	return m["GOPATH"]
 }
 
 func GoBin() string {
 	return Get("GO_BIN", "go")
 }
 
+// GoBinPath returns the directory go install installs binaries in:
+// GOBIN when it's set, or the bin directory of the first GOPATH
+// otherwise. References to other variables, and a leading ~, are
+// expanded. An error is returned when neither is set, or the
+// directory isn't an absolute path, which the go tool requires.
+func GoBinPath() (string, error) {
+	dir := Get("GOBIN", "")
+	if dir == "" {
+		gp := GoPaths()
+		if len(gp) == 0 || gp[0] == "" {
+			return "", errors.New("neither GOBIN nor GOPATH is set")
+		}
+		dir = filepath.Join(gp[0], "bin")
+	}
+
+	dir = env.Expand(dir)
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, dir[1:])
+	}
+	if !filepath.IsAbs(dir) {
+		return "", fmt.Errorf("%s is not an absolute path", dir)
+	}
+	return filepath.Clean(dir), nil
+}
+
+// InGoPath reports whether the current directory is in a GOPATH.
+//
+// Deprecated: comparing path prefixes gives wrong answers with modules,
+// symbolic links, and case-insensitive filesystems. Use ProjectRoot.
 func InGoPath() bool {
 	pwd, _ := os.Getwd()
 	for _, p := range GoPaths() {
@@ -209,26 +233,162 @@ func GoPaths() []string {
 	return strings.Split(gp, ":")
 }
 
-// CurrentModule will attempt to return the module name from `go.mod`.
-// GOPATH isn't supported, no fallback to `CurrentPackage()` anymore.
+// CurrentModule will attempt to return the module name from `go.mod`,
+// the nearest one in the current directory or its parents, see
+// FindModule. GOPATH isn't supported, no fallback to
+// `CurrentPackage()` anymore.
 func CurrentModule() (string, error) {
-	moddata, err := ioutil.ReadFile("go.mod")
+	m, err := currentModule()
+	if err != nil {
+		return "", err
+	}
+	return m.Path, nil
+}
+
+// Module describes the module holding a directory.
+type Module struct {
+	// Path of the module, from its go.mod.
+	Path string
+	// Dir is the directory of the go.mod.
+	Dir string
+	// Rel is the slash separated path of the directory within
+	// the module; the empty string for the module's root.
+	Rel string
+
+	gomod []byte
+}
+
+// ImportPath returns the import path of the package in the directory.
+func (m Module) ImportPath() string {
+	return path.Join(m.Path, m.Rel)
+}
+
+// FindModule returns the module holding dir, the one with the nearest
+// go.mod in dir or its parents, like the go tool finds it.
+func FindModule(dir string) (Module, error) {
+	dir, err := filepath.Abs(dir)
 	if err != nil {
-		return "", errors.New("go.mod cannot be read or does not exist")
+		return Module{}, err
 	}
-	packagePath := modfile.ModulePath(moddata)
-	if packagePath == "" {
-		return "", errors.New("go.mod is malformed")
+	for d := dir; ; {
+		moddata, err := ioutil.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			packagePath := modfile.ModulePath(moddata)
+			if packagePath == "" {
+				return Module{}, errors.New("go.mod is malformed")
+			}
+			rel, err := filepath.Rel(d, dir)
+			if err != nil {
+				return Module{}, err
+			}
+			if rel == "." {
+				rel = ""
+			}
+			return Module{Path: packagePath, Dir: d, Rel: filepath.ToSlash(rel), gomod: moddata}, nil
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return Module{}, errors.New("go.mod cannot be read or does not exist")
+		}
+		d = parent
 	}
-	return packagePath, nil
 }
 
-func Environ() []string {
-	gil.RLock()
-	defer gil.RUnlock()
-	var e []string
-	for k, v := range env {
-		e = append(e, fmt.Sprintf("%s=%s", k, v))
+// ProjectRoot returns the root directory of the project the current
+// directory is in: the directory of the go.work file in use, when
+// there is one, or of the nearest go.mod, or else of the nearest
+// .git. An error is returned when none of them is found.
+func ProjectRoot() (string, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	switch gw := Get("GOWORK", ""); gw {
+	case "off":
+	case "":
+		if dir, ok := findUp(pwd, "go.work"); ok {
+			return dir, nil
+		}
+	default:
+		return filepath.Dir(gw), nil
+	}
+	for _, name := range []string{"go.mod", ".git"} {
+		if dir, ok := findUp(pwd, name); ok {
+			return dir, nil
+		}
+	}
+	return "", errors.New("could not find go.work, go.mod, or .git")
+}
+
+// findUp returns the nearest of dir and its parents that holds name.
+func findUp(dir, name string) (string, bool) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+func currentModule() (Module, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return Module{}, err
+	}
+	return FindModule(pwd)
+}
+
+// ModuleToolchain returns the Go toolchain the current module, see
+// CurrentModule, requires: its toolchain directive, or, as the go tool
+// implies it, "go" followed by its go directive when it has none. An
+// empty string is returned when it has neither. Tools can warn when
+// the local go tool doesn't satisfy it:
+//
+//	tc, err := envy.ModuleToolchain()
+//	if err == nil && tc != "" && !envy.AtLeast(tc) {
+//		log.Printf("go.mod requires %s", tc)
+//	}
+func ModuleToolchain() (string, error) {
+	m, err := currentModule()
+	if err != nil {
+		return "", err
+	}
+	moddata := m.gomod
+	if tc := modDirective(moddata, "toolchain"); tc != "" {
+		return tc, nil
+	}
+	if v := modDirective(moddata, "go"); v != "" {
+		return "go" + v, nil
 	}
-	return e
+	return "", nil
+}
+
+// modDirective returns the argument of the single argument directive
+// name, such as go or toolchain, of the go.mod file data.
+func modDirective(data []byte, name string) string {
+	block := false
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "//"); i != -1 {
+			line = line[:i]
+		}
+		f := strings.Fields(line)
+		switch {
+		case len(f) == 0:
+		case block:
+			block = f[0] != ")"
+		case f[len(f)-1] == "(":
+			block = true
+		case len(f) == 2 && f[0] == name:
+			return f[1]
+		}
+	}
+	return ""
+}
+
+func Environ() []string {
+	return env.Environ()
 }
@@ -1,10 +1,13 @@
 package envy
 
 import (
+	"errors"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -86,6 +89,54 @@ func Test_Temp(t *testing.T) {
 	r.Error(err)
 }
 
+func Test_Temp_Nested(t *testing.T) {
+	r := require.New(t)
+
+	Temp(func() {
+		Set("BAR", "outer")
+		Temp(func() {
+			r.Equal("outer", Get("BAR", ""))
+			Set("BAR", "inner")
+			Set("BAZ", "inner")
+			r.Equal("inner", Get("BAR", ""))
+		})
+		r.Equal("outer", Get("BAR", ""))
+		_, err := MustGet("BAZ")
+		r.Error(err)
+	})
+
+	_, err := MustGet("BAR")
+	r.Error(err)
+}
+
+func Test_TempE(t *testing.T) {
+	r := require.New(t)
+
+	err := TempE(func() error {
+		Set("BAR", "foo")
+		return errors.New("boom")
+	})
+	r.EqualError(err, "boom")
+
+	_, err = MustGet("BAR")
+	r.Error(err)
+
+	r.NoError(TempE(func() error { return nil }))
+}
+
+func Test_TempT(t *testing.T) {
+	r := require.New(t)
+
+	t.Run("set", func(t *testing.T) {
+		TempT(t)
+		Set("BAR", "foo")
+		r.Equal("foo", Get("BAR", "bar"))
+	})
+
+	_, err := MustGet("BAR")
+	r.Error(err)
+}
+
 func Test_GoPath(t *testing.T) {
 	r := require.New(t)
 	Temp(func() {
@@ -106,6 +157,43 @@ func Test_GoPaths(t *testing.T) {
 	})
 }
 
+func Test_GoBinPath(t *testing.T) {
+	r := require.New(t)
+	TempT(t)
+
+	Set("GOBIN", "/go/bin")
+	p, err := GoBinPath()
+	r.NoError(err)
+	r.Equal(filepath.FromSlash("/go/bin"), p)
+
+	Set("GOBIN", "")
+	Set("GOPATH", strings.Join([]string{"/foo", "/bar"}, string(filepath.ListSeparator)))
+	p, err = GoBinPath()
+	r.NoError(err)
+	r.Equal(filepath.FromSlash("/foo/bin"), p)
+
+	Set("GOBIN", "${TOOLS}/bin")
+	Set("TOOLS", "/tools")
+	p, err = GoBinPath()
+	r.NoError(err)
+	r.Equal(filepath.FromSlash("/tools/bin"), p)
+
+	Set("GOBIN", "~/bin")
+	home, _ := os.UserHomeDir()
+	p, err = GoBinPath()
+	r.NoError(err)
+	r.Equal(filepath.Join(home, "bin"), p)
+
+	Set("GOBIN", "relative/bin")
+	_, err = GoBinPath()
+	r.Error(err)
+
+	Set("GOBIN", "")
+	Set("GOPATH", "")
+	_, err = GoBinPath()
+	r.EqualError(err, "neither GOBIN nor GOPATH is set")
+}
+
 func Test_CurrentModule(t *testing.T) {
 	r := require.New(t)
 	mod, err := CurrentModule()
@@ -113,6 +201,104 @@ func Test_CurrentModule(t *testing.T) {
 	r.Equal("github.com/gobuffalo/envy", mod)
 }
 
+func Test_CurrentModule_Nested(t *testing.T) {
+	r := require.New(t)
+
+	pwd, err := os.Getwd()
+	r.NoError(err)
+	r.NoError(os.Chdir("cmd/envy"))
+	defer os.Chdir(pwd)
+
+	mod, err := CurrentModule()
+	r.NoError(err)
+	r.Equal("github.com/gobuffalo/envy", mod)
+}
+
+func Test_FindModule(t *testing.T) {
+	r := require.New(t)
+
+	m, err := FindModule(".")
+	r.NoError(err)
+	r.Equal("github.com/gobuffalo/envy", m.Path)
+	r.Equal("", m.Rel)
+	r.Equal("github.com/gobuffalo/envy", m.ImportPath())
+
+	m, err = FindModule("cmd/envy")
+	r.NoError(err)
+	r.Equal("github.com/gobuffalo/envy", m.Path)
+	r.Equal("cmd/envy", m.Rel)
+	r.Equal("github.com/gobuffalo/envy/cmd/envy", m.ImportPath())
+	pwd, _ := os.Getwd()
+	r.Equal(pwd, m.Dir)
+
+	m, err = FindModule("cobrax")
+	r.NoError(err)
+	r.Equal("github.com/gobuffalo/envy/cobrax", m.Path)
+
+	_, err = FindModule(t.TempDir())
+	r.EqualError(err, "go.mod cannot be read or does not exist")
+}
+
+func Test_ProjectRoot(t *testing.T) {
+	r := require.New(t)
+
+	pwd, err := os.Getwd()
+	r.NoError(err)
+
+	Temp(func() {
+		Set("GOWORK", "off")
+		r.NoError(os.Chdir("cmd/envy"))
+		defer os.Chdir(pwd)
+
+		root, err := ProjectRoot()
+		r.NoError(err)
+		r.Equal(pwd, root)
+	})
+}
+
+func Test_ProjectRoot_Work(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	r.NoError(os.MkdirAll(filepath.Join(dir, "app", "pkg"), 0755))
+	r.NoError(ioutil.WriteFile(filepath.Join(dir, "go.work"), []byte("go 1.21\n\nuse ./app\n"), 0644))
+	r.NoError(ioutil.WriteFile(filepath.Join(dir, "app", "go.mod"), []byte("module app\n"), 0644))
+
+	pwd, err := os.Getwd()
+	r.NoError(err)
+	r.NoError(os.Chdir(filepath.Join(dir, "app", "pkg")))
+	defer os.Chdir(pwd)
+
+	dir, err = filepath.EvalSymlinks(dir)
+	r.NoError(err)
+	Temp(func() {
+		Set("GOWORK", "")
+		root, err := ProjectRoot()
+		r.NoError(err)
+		r.Equal(dir, root)
+
+		Set("GOWORK", "off")
+		root, err = ProjectRoot()
+		r.NoError(err)
+		r.Equal(filepath.Join(dir, "app"), root)
+	})
+}
+
+func Test_ModuleToolchain(t *testing.T) {
+	r := require.New(t)
+	tc, err := ModuleToolchain()
+	r.NoError(err)
+	r.True(strings.HasPrefix(tc, "go1."), tc)
+}
+
+func Test_modDirective(t *testing.T) {
+	r := require.New(t)
+	data := []byte("module example.com/m // the module\n\nrequire (\n\tgo 1.0\n)\n\ngo 1.21\n\ntoolchain go1.22.1 // pinned\n")
+	r.Equal("1.21", modDirective(data, "go"))
+	r.Equal("go1.22.1", modDirective(data, "toolchain"))
+	r.Equal("", modDirective(data, "godebug"))
+}
+
 // Env files loading
 func Test_LoadEnvLoadsEnvFile(t *testing.T) {
 	r := require.New(t)
@@ -160,7 +346,7 @@ func Test_OverloadParams(t *testing.T) {
 func Test_ErrorWhenSingleFileLoadDoesNotExist(t *testing.T) {
 	r := require.New(t)
 	Temp(func() {
-		delete(env, "FLAVOUR")
+		delete(env.vars, "FLAVOUR")
 		err := Load(".env.fake")
 
 		r.Error(err)
@@ -208,7 +394,7 @@ func Test_GOPATH_Not_Set(t *testing.T) {
 
 	Temp(func() {
 		MustSet("GOPATH", "/go")
-		loadEnv()
+		env.loadEnv()
 		r.Equal("/go", Get("GOPATH", "notset"))
 	})
 
@@ -0,0 +1,43 @@
+// Package envyexpvar publishes an envy Env through expvar. It's its
+// own package, as importing expvar registers /debug/vars, which also
+// serves the command line of the program, on http.DefaultServeMux, so
+// only the programs opting in pay for it.
+package envyexpvar
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+
+	"github.com/gobuffalo/envy"
+)
+
+// Publish publishes the Env e, or the default Env when e is nil, as
+// the expvar name, served at /debug/vars, with the values of the
+// secrets redacted, and the denied keys left out, see RedactedMap.
+// The variables are read every time they're served. Like
+// expvar.Publish, it panics if name is already published.
+func Publish(e *envy.Env, name string) {
+	if e == nil {
+		e = envy.Default()
+	}
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return e.RedactedMap()
+	}))
+}
+
+// Handler returns an http.Handler serving the Env e, or the default
+// Env when e is nil, as a JSON object, with the values of the secrets
+// redacted, and the denied keys left out, see RedactedMap, for
+// debugging dashboards.
+func Handler(e *envy.Env) http.Handler {
+	if e == nil {
+		e = envy.Default()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(e.RedactedMap())
+	})
+}
@@ -0,0 +1,38 @@
+package envyexpvar
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gobuffalo/envy"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Publish(t *testing.T) {
+	r := require.New(t)
+
+	e := envy.FromMap(map[string]string{"PORT": "3000", "API_TOKEN": "abc", "LD_PRELOAD": "x"})
+	r.NoError(e.Deny("LD_*"))
+	Publish(e, "envy_test")
+
+	m := map[string]string{}
+	r.NoError(json.Unmarshal([]byte(expvar.Get("envy_test").String()), &m))
+	r.Equal(map[string]string{"PORT": "3000", "API_TOKEN": envy.Redacted}, m)
+
+	e.Set("PORT", "4000")
+	r.NoError(json.Unmarshal([]byte(expvar.Get("envy_test").String()), &m))
+	r.Equal("4000", m["PORT"])
+}
+
+func Test_Handler(t *testing.T) {
+	r := require.New(t)
+
+	e := envy.FromMap(map[string]string{"PORT": "3000", "API_TOKEN": "abc"})
+	w := httptest.NewRecorder()
+	Handler(e).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	r.Equal("application/json", w.Header().Get("Content-Type"))
+	r.Equal("{\n  \"API_TOKEN\": \"***REDACTED***\",\n  \"PORT\": \"3000\"\n}\n", w.Body.String())
+}
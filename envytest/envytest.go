@@ -0,0 +1,36 @@
+// Package envytest provides helpers for testing code that reads
+// its configuration through envy.
+package envytest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gobuffalo/envy"
+)
+
+// Scoped returns a copy of the default envy Env for the test t.
+// Mutations made through the returned Env, including MustSet and
+// Load, are invisible to other tests and to the OS, so tests using
+// it can safely call t.Parallel, unlike tests built on envy.Temp.
+func Scoped(t testing.TB) *envy.Env {
+	t.Helper()
+	return envy.Default().Clone()
+}
+
+// RequireKeys fails the test t immediately if any of the keys are
+// missing, or empty, in the default envy Env. All missing keys are
+// listed in a single message.
+func RequireKeys(t testing.TB, keys ...string) {
+	t.Helper()
+
+	var missing []string
+	for _, k := range keys {
+		if envy.Get(k, "") == "" {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		t.Fatalf("missing required ENV vars: %s", strings.Join(missing, ", "))
+	}
+}
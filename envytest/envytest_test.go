@@ -0,0 +1,57 @@
+package envytest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gobuffalo/envy"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Scoped(t *testing.T) {
+	for _, v := range []string{"one", "two", "three"} {
+		v := v
+		t.Run(v, func(t *testing.T) {
+			t.Parallel()
+			r := require.New(t)
+
+			e := Scoped(t)
+			r.Equal("", e.Get("ENVYTEST_SCOPED", ""))
+
+			r.NoError(e.MustSet("ENVYTEST_SCOPED", v))
+			r.Equal(v, e.Get("ENVYTEST_SCOPED", ""))
+
+			r.Zero(os.Getenv("ENVYTEST_SCOPED"))
+			r.Equal("", envy.Get("ENVYTEST_SCOPED", ""))
+		})
+	}
+}
+
+func Test_Scoped_Load(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+
+	e := Scoped(t)
+	r.NoError(e.Load("../test_env/.env.prod"))
+	r.Equal("production", e.Get("FLAVOUR", ""))
+
+	r.NotEqual("production", os.Getenv("FLAVOUR"))
+	r.NotEqual("production", envy.Get("FLAVOUR", ""))
+}
+
+func Test_RequireKeys(t *testing.T) {
+	r := require.New(t)
+
+	envy.TempT(t)
+	envy.Set("ENVYTEST_PRESENT", "yes")
+	envy.Set("ENVYTEST_EMPTY", "")
+
+	ft := &fakeTB{TB: t}
+	RequireKeys(ft, "ENVYTEST_PRESENT")
+	r.False(ft.failed)
+
+	ft = &fakeTB{TB: t}
+	RequireKeys(ft, "ENVYTEST_MISSING", "ENVYTEST_PRESENT", "ENVYTEST_EMPTY")
+	r.True(ft.failed)
+	r.Equal("missing required ENV vars: ENVYTEST_MISSING, ENVYTEST_EMPTY", ft.msg)
+}
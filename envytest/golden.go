@@ -0,0 +1,64 @@
+package envytest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/gobuffalo/envy"
+)
+
+var update = flag.Bool("envytest.update", false, "update envytest golden files")
+
+// Golden compares a sorted dump of e, with the values of its secrets
+// redacted, against the golden file at path, failing t if they
+// differ, or if the golden file doesn't exist. The golden file is
+// only written when the tests are run with the -envytest.update flag.
+func Golden(t testing.TB, e *envy.Env, path string) {
+	t.Helper()
+
+	got := dump(e)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("could not create golden file %s: %s", path, err)
+			return
+		}
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("could not write golden file %s: %s", path, err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("golden file %s missing, run with -envytest.update to write it", path)
+		return
+	}
+	if err != nil {
+		t.Fatalf("could not read golden file %s: %s", path, err)
+		return
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("env does not match golden file %s (run with -envytest.update to update it)\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+func dump(e *envy.Env) []byte {
+	m := e.RedactedMap()
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	bb := &bytes.Buffer{}
+	for _, k := range keys {
+		fmt.Fprintf(bb, "%s=%q\n", k, m[k])
+	}
+	return bb.Bytes()
+}
@@ -0,0 +1,66 @@
+package envytest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gobuffalo/envy"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTB struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.failed = true
+}
+
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.failed = true
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func Test_Golden(t *testing.T) {
+	r := require.New(t)
+
+	e := Scoped(t)
+	e.Set("ENVYTEST_GOLDEN", "gold")
+	e.Set("ENVYTEST_API_TOKEN", "hunter2")
+
+	path := filepath.Join(t.TempDir(), "testdata", "env.golden")
+	ft := &fakeTB{TB: t}
+	Golden(ft, e, path)
+	r.True(ft.failed)
+	r.Contains(ft.msg, "golden file "+path+" missing")
+	_, err := os.Stat(path)
+	r.True(os.IsNotExist(err))
+
+	*update = true
+	Golden(t, e, path)
+	*update = false
+
+	b, err := ioutil.ReadFile(path)
+	r.NoError(err)
+	r.Contains(string(b), fmt.Sprintf("ENVYTEST_GOLDEN=%q\n", "gold"))
+	r.Contains(string(b), fmt.Sprintf("ENVYTEST_API_TOKEN=%q\n", envy.Redacted))
+	r.NotContains(string(b), "hunter2")
+
+	// secrets are redacted, so changing them doesn't break the golden file
+	e.Set("ENVYTEST_API_TOKEN", "hunter3")
+	ft = &fakeTB{TB: t}
+	Golden(ft, e, path)
+	r.False(ft.failed)
+
+	e.Set("ENVYTEST_GOLDEN", "silver")
+	ft = &fakeTB{TB: t}
+	Golden(ft, e, path)
+	r.True(ft.failed)
+}
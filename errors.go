@@ -0,0 +1,193 @@
+package envy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SourceError annotates an error with the source of variables, such
+// as the path of a file, the name of a provider, or a URL, it's about,
+// so it reads "vault kv/myapp: permission denied" in the logs, rather
+// than just "permission denied".
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+func (e SourceError) Error() string {
+	return e.Source + ": " + e.Err.Error()
+}
+
+func (e SourceError) Unwrap() error {
+	return e.Err
+}
+
+// withSource annotates err with source, unless it's nil,
+// or already names its source.
+func withSource(source string, err error) error {
+	if err == nil || ErrorSource(err) != "" {
+		return err
+	}
+	return SourceError{Source: source, Err: err}
+}
+
+// ErrorSource returns the source of variables err is about, such as
+// the path of the file of an ErrParse, or the empty string when err
+// doesn't name one.
+func ErrorSource(err error) string {
+	var se SourceError
+	var fnf ErrFileNotFound
+	var pe ErrParse
+	switch {
+	case errors.As(err, &se):
+		return se.Source
+	case errors.As(err, &fnf):
+		return fnf.Path
+	case errors.As(err, &pe):
+		return pe.File
+	}
+	return ""
+}
+
+// ErrKeyNotFound is returned when a key that must be set isn't, such
+// as by MustGet. errors.Is(err, ErrKeyNotFound{}) reports whether err
+// is one, for any key.
+type ErrKeyNotFound struct {
+	Key string
+}
+
+func (e ErrKeyNotFound) Error() string {
+	return fmt.Sprintf("could not find ENV var with %s", e.Key)
+}
+
+// Is reports whether target is an ErrKeyNotFound for the
+// same key, or for any key when its Key is empty.
+func (e ErrKeyNotFound) Is(target error) bool {
+	t, ok := target.(ErrKeyNotFound)
+	return ok && (t.Key == "" || t.Key == e.Key)
+}
+
+// ErrFileNotFound is returned by Load when a file doesn't exist.
+// errors.Is(err, ErrFileNotFound{}) reports whether err is one, for
+// any path, and, as it wraps the error of the OS, so does
+// errors.Is(err, fs.ErrNotExist).
+type ErrFileNotFound struct {
+	Path string
+	Err  error
+}
+
+func (e ErrFileNotFound) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("could not find %s", e.Path)
+}
+
+func (e ErrFileNotFound) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an ErrFileNotFound for the
+// same path, or for any path when its Path is empty.
+func (e ErrFileNotFound) Is(target error) bool {
+	t, ok := target.(ErrFileNotFound)
+	return ok && (t.Path == "" || t.Path == e.Path)
+}
+
+// ErrParse is returned when a file can't be parsed, such as by
+// NativeLoader. errors.Is(err, ErrParse{}) reports whether err
+// is one, for any file.
+type ErrParse struct {
+	File string
+	// Line and Column, starting at 1, where the error was found,
+	// when they're known.
+	Line   int
+	Column int
+	// Key whose value can't be parsed, when it's known.
+	Key string
+	// Content of the offending line. As it may hold a secret,
+	// it isn't part of the message of the error.
+	Content string
+	Err     error
+}
+
+func (e ErrParse) Error() string {
+	bb := &strings.Builder{}
+	bb.WriteString(e.File)
+	if e.Line > 0 {
+		fmt.Fprintf(bb, ":%d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(bb, ":%d", e.Column)
+		}
+	}
+	bb.WriteString(": ")
+	if e.Key != "" {
+		bb.WriteString(e.Key + ": ")
+	}
+	bb.WriteString(e.Err.Error())
+	return bb.String()
+}
+
+func (e ErrParse) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an ErrParse for the same
+// file, or for any file when its File is empty.
+func (e ErrParse) Is(target error) bool {
+	t, ok := target.(ErrParse)
+	return ok && (t.File == "" || t.File == e.File)
+}
+
+// joinErrors joins errs like errors.Join, which needs Go 1.20,
+// returning nil when all of them are nil.
+func joinErrors(errs ...error) error {
+	je := &joinError{}
+	for _, err := range errs {
+		if err != nil {
+			je.errs = append(je.errs, err)
+		}
+	}
+	if len(je.errs) == 0 {
+		return nil
+	}
+	return je
+}
+
+// joinError is the error returned by joinErrors. Its Is and As look
+// through each of the errors, as errors.Is and errors.As only do it
+// themselves as of Go 1.20.
+type joinError struct {
+	errs []error
+}
+
+func (e *joinError) Error() string {
+	s := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
+
+func (e *joinError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *joinError) As(target interface{}) bool {
+	for _, err := range e.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
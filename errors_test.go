@@ -0,0 +1,111 @@
+package envy
+
+import (
+	"errors"
+	"io/fs"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ErrKeyNotFound(t *testing.T) {
+	r := require.New(t)
+
+	_, err := FromMap(nil).MustGet("NOPE")
+	r.EqualError(err, "could not find ENV var with NOPE")
+	r.True(errors.Is(err, ErrKeyNotFound{}))
+	r.True(errors.Is(err, ErrKeyNotFound{Key: "NOPE"}))
+	r.False(errors.Is(err, ErrKeyNotFound{Key: "OTHER"}))
+
+	var knf ErrKeyNotFound
+	r.True(errors.As(err, &knf))
+	r.Equal("NOPE", knf.Key)
+}
+
+func Test_ErrFileNotFound(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(nil)
+	err := e.Load("test_env/.env.nope")
+	r.EqualError(err, "stat test_env/.env.nope: no such file or directory")
+	r.True(errors.Is(err, ErrFileNotFound{}))
+	r.True(errors.Is(err, ErrFileNotFound{Path: "test_env/.env.nope"}))
+	r.True(errors.Is(err, fs.ErrNotExist))
+
+	var fnf ErrFileNotFound
+	r.True(errors.As(err, &fnf))
+	r.Equal("test_env/.env.nope", fnf.Path)
+
+	r.EqualError(ErrFileNotFound{Path: ".env"}, "could not find .env")
+}
+
+func Test_ErrParse(t *testing.T) {
+	r := require.New(t)
+
+	path := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(path, []byte("A=1\nB\n"), 0600))
+
+	e := New(WithOSEnv(false), WithLoader(NativeLoader{}))
+	err := e.Load(path)
+	r.EqualError(err, path+":2:1: can't separate key from value")
+	r.True(errors.Is(err, ErrParse{}))
+	r.True(errors.Is(err, ErrParse{File: path}))
+	r.False(errors.Is(err, ErrFileNotFound{}))
+
+	var pe ErrParse
+	r.True(errors.As(err, &pe))
+	r.Equal(2, pe.Line)
+	r.Equal(1, pe.Column)
+	r.Equal("B", pe.Content)
+
+	// godotenv doesn't report the line, it's located with ParseLine
+	err = Load(path)
+	r.EqualError(err, path+":2:1: can't separate key from value")
+	pe = ErrParse{}
+	r.True(errors.As(err, &pe))
+	r.Equal(path, pe.File)
+	r.Equal(2, pe.Line)
+	r.Equal("B", pe.Content)
+}
+
+func Test_SourceError(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithLoader(LoaderFunc(func(path string) (map[string]string, error) {
+		return nil, errors.New("permission denied")
+	})))
+	err := e.Load("test_env/.env")
+	r.EqualError(err, "test_env/.env: permission denied")
+	r.Equal("test_env/.env", ErrorSource(err))
+
+	var se SourceError
+	r.True(errors.As(err, &se))
+	r.EqualError(se.Err, "permission denied")
+
+	err = e.Load("test_env/.env.nope")
+	r.Equal("test_env/.env.nope", ErrorSource(err))
+	r.False(errors.As(err, &se))
+
+	r.Equal("", ErrorSource(errors.New("boom")))
+	r.Equal("", ErrorSource(nil))
+	r.Nil(withSource("x", nil))
+}
+
+func Test_joinErrors(t *testing.T) {
+	r := require.New(t)
+
+	r.Nil(joinErrors())
+	r.Nil(joinErrors(nil, nil))
+
+	err := joinErrors(ErrKeyNotFound{Key: "A"}, nil, ErrParse{File: ".env", Err: errors.New("bad")})
+	r.EqualError(err, "could not find ENV var with A\n.env: bad")
+	r.True(errors.Is(err, ErrKeyNotFound{}))
+	r.True(errors.Is(err, ErrParse{File: ".env"}))
+	r.False(errors.Is(err, ErrFileNotFound{}))
+
+	var pe ErrParse
+	r.True(errors.As(err, &pe))
+	r.Equal(".env", pe.File)
+}
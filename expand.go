@@ -0,0 +1,47 @@
+package envy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Expand replaces the ${VAR} and $VAR references in s with the values
+// in the Env, like os.ExpandEnv does with the OS environment. Unknown
+// variables are replaced with an empty string.
+func (e *Env) Expand(s string) string {
+	return os.Expand(s, func(key string) string {
+		return e.Get(key, "")
+	})
+}
+
+// Expand replaces the ${VAR} and $VAR
+// references in s with the values in envy.
+func Expand(s string) string {
+	return env.Expand(s)
+}
+
+// ExpandStrict is like Expand, but returns an error naming
+// all of the unknown variables s references.
+func (e *Env) ExpandStrict(s string) (string, error) {
+	var missing []string
+	seen := map[string]bool{}
+	x := os.Expand(s, func(key string) string {
+		v, ok := e.Lookup(key)
+		if !ok && !seen[key] {
+			seen[key] = true
+			missing = append(missing, key)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("unknown ENV vars: %s", strings.Join(missing, ", "))
+	}
+	return x, nil
+}
+
+// ExpandStrict is like Expand, but returns an error
+// naming all of the unknown variables s references.
+func ExpandStrict(s string) (string, error) {
+	return env.ExpandStrict(s)
+}
@@ -0,0 +1,28 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Expand(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"HOST": "localhost", "PORT": "3000", "EMPTY": ""})
+	r.Equal("http://localhost:3000/", e.Expand("http://${HOST}:$PORT/"))
+	r.Equal("http://:3000/", e.Expand("http://${NOPE}:$PORT/"))
+	r.Equal("no refs", e.Expand("no refs"))
+}
+
+func Test_Env_ExpandStrict(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"HOST": "localhost", "EMPTY": ""})
+	s, err := e.ExpandStrict("${HOST}${EMPTY}")
+	r.NoError(err)
+	r.Equal("localhost", s)
+
+	_, err = e.ExpandStrict("$USER_X:${PORT_X} $USER_X")
+	r.EqualError(err, "unknown ENV vars: USER_X, PORT_X")
+}
@@ -0,0 +1,282 @@
+package envy
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces the values of secrets when they are exported.
+const Redacted = "***REDACTED***"
+
+// ExportOptions controls which variables the To* serializers
+// write, and how.
+type ExportOptions struct {
+	// Keys limits the export to the keys matching one of these glob
+	// patterns, see path.Match. All keys are exported when empty.
+	Keys []string
+	// Redact replaces the values of the keys matching one of these
+	// glob patterns with Redacted.
+	Redact []string
+	// RedactSecrets replaces the values of the secrets, see
+	// Env.MarkSecret, and the passwords of the URLs, such as the
+	// value of DATABASE_URL, with Redacted.
+	RedactSecrets bool
+	// StripPrefix is removed from the keys starting with it, so
+	// MYAPP_PORT is exported as PORT with "MYAPP_". The other keys
+	// are exported unchanged.
+	StripPrefix string
+	// AddPrefix is prepended to the keys, after StripPrefix is
+	// removed, for subprocesses with other naming conventions.
+	AddPrefix string
+}
+
+// exportName returns the name key is exported as.
+func (opts ExportOptions) exportName(key string) string {
+	return opts.AddPrefix + strings.TrimPrefix(key, opts.StripPrefix)
+}
+
+// Export returns a copy of the variables selected by opts, redacted
+// as requested. It's the common ground of the To* serializers, for
+// formats envy doesn't support.
+func (e *Env) Export(opts ExportOptions) (map[string]string, error) {
+	m, _, err := e.export(opts)
+	return m, err
+}
+
+// export returns the variables selected by opts, and their sorted keys.
+func (e *Env) export(opts ExportOptions) (map[string]string, []string, error) {
+	m, keys, _, err := e.exportRedacted(opts)
+	return m, keys, err
+}
+
+// exportRedacted is like export, but also returns the
+// keys, as exported, whose values were redacted.
+func (e *Env) exportRedacted(opts ExportOptions) (map[string]string, []string, map[string]bool, error) {
+	for _, p := range append(append([]string{}, opts.Keys...), opts.Redact...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+	}
+
+	m := map[string]string{}
+	from := map[string]string{}
+	redacted := map[string]bool{}
+	keys := []string{}
+	for k, v := range e.Map() {
+		if len(opts.Keys) > 0 && !matchAny(opts.Keys, k) {
+			continue
+		}
+		name := opts.exportName(k)
+		if o, ok := from[name]; ok {
+			if o > k {
+				o, k = k, o
+			}
+			return nil, nil, nil, fmt.Errorf("%s and %s are both exported as %s", o, k, name)
+		}
+		from[name] = k
+		if v != "" && matchAny(opts.Redact, k) {
+			v = Redacted
+			redacted[name] = true
+		} else if opts.RedactSecrets {
+			var ok bool
+			e.moot.RLock()
+			v, ok = e.redactValue(k, v)
+			e.moot.RUnlock()
+			if ok {
+				redacted[name] = true
+			}
+		}
+		m[name] = v
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return m, keys, redacted, nil
+}
+
+// ExportEnviron returns the variables selected by opts, see Export,
+// as a sorted list of "key=value" strings, for the exec.Cmd.Env of
+// subprocesses that mustn't inherit the whole Env, see ForCommand. It
+// replaces CmdEnv, and can rename the keys for the subprocess, with
+// the variables only it needs overlaid, see Overlay:
+//
+//	cmd.Env, err = e.Overlay(map[string]string{"MYAPP_MODE": "worker"}).ExportEnviron(envy.ExportOptions{
+//		Keys:        []string{"PATH", "HOME", "MYAPP_*"},
+//		StripPrefix: "MYAPP_",
+//	})
+func (e *Env) ExportEnviron(opts ExportOptions) ([]string, error) {
+	m, _, err := e.export(opts)
+	if err != nil {
+		return nil, err
+	}
+	return environ(m), nil
+}
+
+func matchAny(patterns []string, key string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ToJSON writes the Env to w as an indented JSON object, sorted by key.
+func (e *Env) ToJSON(w io.Writer, opts ExportOptions) error {
+	m, _, err := e.export(opts)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// ToYAML writes the Env to w as a YAML mapping, sorted by key.
+func (e *Env) ToYAML(w io.Writer, opts ExportOptions) error {
+	m, _, err := e.export(opts)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// ToTOML writes the Env to w as TOML key/value pairs, sorted by key.
+// All of the values are written as TOML strings.
+func (e *Env) ToTOML(w io.Writer, opts ExportOptions) error {
+	m, keys, err := e.export(opts)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	for _, k := range keys {
+		tk := k
+		if !isBareTOMLKey(k) {
+			tk = tomlString(k)
+		}
+		fmt.Fprintf(bw, "%s = %s\n", tk, tomlString(m[k]))
+	}
+	return bw.Flush()
+}
+
+func isBareTOMLKey(k string) bool {
+	if len(k) == 0 {
+		return false
+	}
+	for _, c := range k {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// tomlString quotes s as a TOML basic string.
+func tomlString(s string) string {
+	bb := &strings.Builder{}
+	bb.WriteByte('"')
+	for _, c := range s {
+		switch c {
+		case '"':
+			bb.WriteString(`\"`)
+		case '\\':
+			bb.WriteString(`\\`)
+		case '\n':
+			bb.WriteString(`\n`)
+		case '\r':
+			bb.WriteString(`\r`)
+		case '\t':
+			bb.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				fmt.Fprintf(bb, `\u%04X`, c)
+				continue
+			}
+			bb.WriteRune(c)
+		}
+	}
+	bb.WriteByte('"')
+	return bb.String()
+}
+
+// ToShell writes the Env to w as a POSIX shell script of export
+// statements, sorted by key, with the values single quoted so the
+// output can safely be eval'd. An error is returned for keys that
+// aren't valid shell variable names.
+func (e *Env) ToShell(w io.Writer, opts ExportOptions) error {
+	m, keys, err := e.export(opts)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if !isVarName(k) {
+			return fmt.Errorf("%q is not a valid shell variable name", k)
+		}
+	}
+	bw := bufio.NewWriter(w)
+	for _, k := range keys {
+		fmt.Fprintf(bw, "export %s='%s'\n", k, strings.ReplaceAll(m[k], "'", `'\''`))
+	}
+	return bw.Flush()
+}
+
+// ToDockerEnvFile writes the Env to w in the restricted KEY=value
+// format accepted by the --env-file flag of docker and podman, sorted
+// by key. That format has no quoting, so an error is returned, and
+// nothing is written, if a key or a value can't be represented.
+func (e *Env) ToDockerEnvFile(w io.Writer, opts ExportOptions) error {
+	m, keys, err := e.export(opts)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if k == "" || k[0] == '#' || strings.ContainsAny(k, "= \t\r\n\x00") {
+			return fmt.Errorf("%q can't be used as a key in a docker env file", k)
+		}
+		if strings.ContainsAny(m[k], "\r\n\x00") {
+			return fmt.Errorf("the value of %s can't be represented in a docker env file", k)
+		}
+	}
+	bw := bufio.NewWriter(w)
+	for _, k := range keys {
+		fmt.Fprintf(bw, "%s=%s\n", k, m[k])
+	}
+	return bw.Flush()
+}
+
+var systemdQuoter = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "`", "\\`")
+
+// ToSystemd writes the Env to w in the format of the EnvironmentFile
+// directive of systemd units, sorted by key. Values are double quoted,
+// with the characters systemd treats specially escaped; newlines are
+// kept literally, which systemd allows inside quotes. An error is
+// returned for keys systemd doesn't accept.
+func (e *Env) ToSystemd(w io.Writer, opts ExportOptions) error {
+	m, keys, err := e.export(opts)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if !isVarName(k) {
+			return fmt.Errorf("%q is not a valid systemd environment variable name", k)
+		}
+	}
+	bw := bufio.NewWriter(w)
+	for _, k := range keys {
+		fmt.Fprintf(bw, "%s=\"%s\"\n", k, systemdQuoter.Replace(m[k]))
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,179 @@
+package envy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func exportEnv() *Env {
+	return FromMap(map[string]string{
+		"APP_NAME":  "envy",
+		"APP_TOKEN": "hunter2",
+		"APP_EMPTY": "",
+		"HOME":      "/home/envy",
+	})
+}
+
+func Test_Env_ToJSON(t *testing.T) {
+	r := require.New(t)
+
+	bb := &bytes.Buffer{}
+	r.NoError(exportEnv().ToJSON(bb, ExportOptions{}))
+	r.Equal(`{
+  "APP_EMPTY": "",
+  "APP_NAME": "envy",
+  "APP_TOKEN": "hunter2",
+  "HOME": "/home/envy"
+}
+`, bb.String())
+
+	bb.Reset()
+	r.NoError(exportEnv().ToJSON(bb, ExportOptions{
+		Keys:   []string{"APP_*"},
+		Redact: []string{"*_TOKEN", "*_EMPTY"},
+	}))
+	r.Equal(`{
+  "APP_EMPTY": "",
+  "APP_NAME": "envy",
+  "APP_TOKEN": "***REDACTED***"
+}
+`, bb.String())
+
+	r.Error(exportEnv().ToJSON(bb, ExportOptions{Keys: []string{"["}}))
+}
+
+func Test_Env_ToYAML(t *testing.T) {
+	r := require.New(t)
+
+	e := exportEnv()
+	e.Set("APP_MULTI", "a\nb")
+	e.Set("APP_BOOL", "true")
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.ToYAML(bb, ExportOptions{Keys: []string{"APP_*"}, Redact: []string{"*_TOKEN"}}))
+	r.Equal(`APP_BOOL: "true"
+APP_EMPTY: ""
+APP_MULTI: |-
+  a
+  b
+APP_NAME: envy
+APP_TOKEN: '***REDACTED***'
+`, bb.String())
+}
+
+func Test_Env_ToTOML(t *testing.T) {
+	r := require.New(t)
+
+	e := exportEnv()
+	e.Set("APP_QUOTES", "say \"hi\"\n\\o/\x01")
+	e.Set("app.dotted", "x")
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.ToTOML(bb, ExportOptions{Keys: []string{"APP_*", "app.*"}, Redact: []string{"*_TOKEN"}}))
+	r.Equal(`APP_EMPTY = ""
+APP_NAME = "envy"
+APP_QUOTES = "say \"hi\"\n\\o/\u0001"
+APP_TOKEN = "***REDACTED***"
+"app.dotted" = "x"
+`, bb.String())
+}
+
+func Test_Env_ToShell(t *testing.T) {
+	r := require.New(t)
+
+	e := exportEnv()
+	e.Set("APP_QUOTE", "it's $HOME\n")
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.ToShell(bb, ExportOptions{Keys: []string{"APP_*"}, Redact: []string{"*_TOKEN"}}))
+	r.Equal(`export APP_EMPTY=''
+export APP_NAME='envy'
+export APP_QUOTE='it'\''s $HOME
+'
+export APP_TOKEN='***REDACTED***'
+`, bb.String())
+
+	e.Set("app.dotted", "x")
+	bb.Reset()
+	r.Error(e.ToShell(bb, ExportOptions{}))
+	r.Empty(bb.String())
+}
+
+func Test_Env_ToDockerEnvFile(t *testing.T) {
+	r := require.New(t)
+
+	e := exportEnv()
+	e.Set("APP_RAW", `"quoted" $NOT_EXPANDED # not a comment`)
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.ToDockerEnvFile(bb, ExportOptions{Keys: []string{"APP_*"}, Redact: []string{"*_TOKEN"}}))
+	r.Equal(`APP_EMPTY=
+APP_NAME=envy
+APP_RAW="quoted" $NOT_EXPANDED # not a comment
+APP_TOKEN=***REDACTED***
+`, bb.String())
+
+	e.Set("APP_MULTI", "a\nb")
+	bb.Reset()
+	r.Error(e.ToDockerEnvFile(bb, ExportOptions{}))
+	r.Empty(bb.String())
+
+	e = FromMap(map[string]string{"A KEY": "x"})
+	r.Error(e.ToDockerEnvFile(bb, ExportOptions{}))
+}
+
+func Test_Env_ToSystemd(t *testing.T) {
+	r := require.New(t)
+
+	e := exportEnv()
+	e.Set("APP_SPECIAL", "say \"hi\" to $USER `now` \\o/\nbye")
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.ToSystemd(bb, ExportOptions{Keys: []string{"APP_*"}, Redact: []string{"*_TOKEN"}}))
+	r.Equal("APP_EMPTY=\"\"\n"+
+		"APP_NAME=\"envy\"\n"+
+		"APP_SPECIAL=\"say \\\"hi\\\" to \\$USER \\`now\\` \\\\o/\nbye\"\n"+
+		"APP_TOKEN=\"***REDACTED***\"\n", bb.String())
+
+	e.Set("app-dashed", "x")
+	r.Error(e.ToSystemd(bb, ExportOptions{}))
+}
+
+func Test_Env_Export_Prefix(t *testing.T) {
+	r := require.New(t)
+
+	m, err := exportEnv().Export(ExportOptions{
+		Keys:        []string{"APP_*"},
+		Redact:      []string{"APP_TOKEN"},
+		StripPrefix: "APP_",
+	})
+	r.NoError(err)
+	r.Equal(map[string]string{"NAME": "envy", "TOKEN": Redacted, "EMPTY": ""}, m)
+
+	m, err = exportEnv().Export(ExportOptions{
+		Keys:        []string{"APP_NAME", "HOME"},
+		StripPrefix: "APP_",
+		AddPrefix:   "SVC_",
+	})
+	r.NoError(err)
+	r.Equal(map[string]string{"SVC_NAME": "envy", "SVC_HOME": "/home/envy"}, m)
+
+	e := exportEnv()
+	e.Set("NAME", "other")
+	_, err = e.Export(ExportOptions{StripPrefix: "APP_"})
+	r.EqualError(err, "APP_NAME and NAME are both exported as NAME")
+}
+
+func Test_Env_ExportEnviron(t *testing.T) {
+	r := require.New(t)
+
+	x, err := exportEnv().ExportEnviron(ExportOptions{
+		Keys:        []string{"APP_*"},
+		StripPrefix: "APP_",
+		AddPrefix:   "CHILD_",
+	})
+	r.NoError(err)
+	r.Equal([]string{"CHILD_EMPTY=", "CHILD_NAME=envy", "CHILD_TOKEN=hunter2"}, x)
+}
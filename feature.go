@@ -0,0 +1,88 @@
+package envy
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// Flag is a feature flag kept in an ENV variable, see Feature.
+type Flag struct {
+	env *Env
+	key string
+}
+
+// Feature returns the feature flag kept in key, formalizing the
+// variable as feature flag pattern. Its value is either a boolean,
+// as parsed by strconv.ParseBool, enabling, or disabling, the feature
+// for everyone, or a percentage, such as 25%, see ParsePercent,
+// rolling it out to part of the users, see EnabledFor:
+//
+//	if envy.Feature("NEW_CHECKOUT").EnabledFor(user.ID) {
+//		...
+//	}
+//
+// A flag that isn't set, or set to anything else, is disabled.
+func (e *Env) Feature(key string) *Flag {
+	return &Flag{env: e, key: key}
+}
+
+// Feature returns the feature flag kept in key in envy.
+func Feature(key string) *Flag {
+	return env.Feature(key)
+}
+
+// Key returns the key the flag is kept in.
+func (f *Flag) Key() string {
+	return f.key
+}
+
+// Percent returns the percentage of the users the feature is
+// enabled for, from 0 to 100: 100 when it's enabled for everyone,
+// and 0 when it's disabled. Other than booleans, the value is parsed
+// like GetPercent does, see ParsePercent, so 25%, 0.25, and 25 all
+// roll the feature out to a quarter of the users.
+func (f *Flag) Percent() float64 {
+	v := f.env.Get(f.key, "")
+	if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+		if b {
+			return 100
+		}
+		return 0
+	}
+	p, err := ParsePercent(v)
+	if err != nil {
+		return 0
+	}
+	return p * 100
+}
+
+// Enabled reports whether the feature is enabled for everyone.
+func (f *Flag) Enabled() bool {
+	return f.Percent() >= 100
+}
+
+// EnabledFor reports whether the feature is enabled for the user, or
+// any other unit of the rollout, with the given id. Whether it is, for
+// a given percentage, is stable, and the users it's enabled for at a
+// percentage are still enabled once it's raised.
+func (f *Flag) EnabledFor(id string) bool {
+	p := f.Percent()
+	if p <= 0 {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(f.key + ":" + id))
+	return float64(h.Sum32()%10000) < p*100
+}
+
+// OnChange registers fn to be called with every change made to the
+// value of the flag, like the hooks registered with Env.OnChange.
+func (f *Flag) OnChange(fn func(Change)) {
+	e := f.env
+	e.OnChange(func(c Change) {
+		if c.Key == f.key || (e.fold && strings.EqualFold(c.Key, f.key)) {
+			fn(c)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package envy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Feature(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"ON":      "true",
+		"OFF":     "0",
+		"HALF":    "50%",
+		"ALL":     "100%",
+		"INVALID": "maybe",
+	})
+
+	r.True(e.Feature("ON").Enabled())
+	r.True(e.Feature("ON").EnabledFor("a"))
+	r.True(e.Feature("ALL").Enabled())
+	for _, k := range []string{"OFF", "INVALID", "MISSING"} {
+		f := e.Feature(k)
+		r.False(f.Enabled(), k)
+		r.False(f.EnabledFor("a"), k)
+		r.Equal(0.0, f.Percent(), k)
+	}
+
+	f := e.Feature("HALF")
+	r.Equal("HALF", f.Key())
+	r.Equal(50.0, f.Percent())
+	r.False(f.Enabled())
+
+	var half []string
+	for i := 0; i < 1000; i++ {
+		id := fmt.Sprint(i)
+		if f.EnabledFor(id) {
+			half = append(half, id)
+		}
+		r.Equal(f.EnabledFor(id), f.EnabledFor(id))
+	}
+	r.InDelta(500, len(half), 75)
+
+	// raising the percentage keeps the users it was enabled for
+	e.Set("HALF", "75%")
+	for _, id := range half {
+		r.True(f.EnabledFor(id))
+	}
+}
+
+func Test_Flag_Percent_ParsePercent(t *testing.T) {
+	r := require.New(t)
+
+	// a flag is rolled out to the percentage GetPercent reads,
+	// and disabled for the values it rejects
+	for _, v := range []string{"25%", " 25 %", "0.25", "25", "0.5%", "100", "0%", "150%", "-5%", "NaN", "abc", ""} {
+		e := FromMap(map[string]string{"FLAG": v})
+		want := 0.0
+		if p, err := ParsePercent(v); err == nil {
+			want = p * 100
+		}
+		r.InDelta(want, e.Feature("FLAG").Percent(), 1e-9, v)
+	}
+}
+
+func Test_Flag_OnChange(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(nil)
+	var changes []Change
+	e.Feature("NEW_CHECKOUT").OnChange(func(c Change) {
+		changes = append(changes, c)
+	})
+	e.Set("OTHER", "true")
+	e.Set("NEW_CHECKOUT", "25%")
+	e.Set("NEW_CHECKOUT", "true")
+	r.Equal([]Change{
+		{Op: Added, Key: "NEW_CHECKOUT", New: "25%"},
+		{Op: Changed, Key: "NEW_CHECKOUT", Old: "25%", New: "true"},
+	}, changes)
+}
@@ -0,0 +1,80 @@
+package envy
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// FlagKey returns the key of the ENV variable for the flag name:
+// the name uppercased, with its dashes and dots replaced by
+// underscores, and prefixed with prefix and an underscore, unless
+// prefix is empty. FlagKey("MYAPP", "db-host") is "MYAPP_DB_HOST".
+func FlagKey(prefix, name string) string {
+	k := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+	if prefix == "" {
+		return k
+	}
+	return strings.TrimSuffix(prefix, "_") + "_" + k
+}
+
+// BindFlagSet sets the flags of fs that haven't been set yet from
+// their ENV variables, see FlagKey. It must be called before fs is
+// parsed, so the command line takes precedence over the Env, which
+// takes precedence over the flags' defaults. It returns an error
+// when a value isn't valid for its flag.
+//
+//	fs := flag.NewFlagSet("myapp", flag.ExitOnError)
+//	host := fs.String("db-host", "localhost", "database host")
+//	if err := envy.Default().BindFlagSet(fs, "MYAPP"); err != nil {
+//		log.Fatal(err)
+//	}
+//	fs.Parse(os.Args[1:])
+func (e *Env) BindFlagSet(fs *flag.FlagSet, prefix string) error {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, f.Name)
+	})
+	_, err := e.BindFlags(prefix, names, func(name string) bool {
+		return set[name]
+	}, fs.Set)
+	return err
+}
+
+// BindFlags is BindFlagSet for other flag packages, such as pflag:
+// the flags with the given names that haven't been changed yet are
+// set, with set, from their ENV variables, see FlagKey. It returns
+// the keys the flags that were set got their values from, by name.
+//
+//	fs := cmd.PersistentFlags()
+//	var names []string
+//	fs.VisitAll(func(f *pflag.Flag) { names = append(names, f.Name) })
+//	_, err := envy.Default().BindFlags("MYAPP", names, fs.Changed, fs.Set)
+func (e *Env) BindFlags(prefix string, names []string, changed func(name string) bool, set func(name, value string) error) (map[string]string, error) {
+	bound := map[string]string{}
+	for _, name := range names {
+		if changed(name) {
+			continue
+		}
+		k := FlagKey(prefix, name)
+		v, ok := e.Lookup(k)
+		if !ok {
+			continue
+		}
+		if err := set(name, v); err != nil {
+			return bound, fmt.Errorf("invalid value %q for flag -%s from %s: %w", v, name, k, err)
+		}
+		bound[name] = k
+	}
+	return bound, nil
+}
+
+// BindFlagSet sets the flags of fs that haven't
+// been set yet from their ENV variables.
+func BindFlagSet(fs *flag.FlagSet, prefix string) error {
+	return env.BindFlagSet(fs, prefix)
+}
@@ -0,0 +1,76 @@
+package envy
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FlagKey(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal("MYAPP_DB_HOST", FlagKey("MYAPP", "db-host"))
+	r.Equal("MYAPP_DB_HOST", FlagKey("MYAPP_", "db.host"))
+	r.Equal("PORT", FlagKey("", "port"))
+}
+
+func Test_Env_BindFlagSet(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"MYAPP_DB_HOST": "db.example.com",
+		"MYAPP_PORT":    "4000",
+		"MYAPP_TIMEOUT": "5s",
+	})
+
+	fs := flag.NewFlagSet("myapp", flag.ContinueOnError)
+	host := fs.String("db-host", "localhost", "")
+	port := fs.Int("port", 3000, "")
+	timeout := fs.Duration("timeout", time.Second, "")
+	debug := fs.Bool("debug", false, "")
+
+	r.NoError(e.BindFlagSet(fs, "MYAPP"))
+	r.NoError(fs.Parse([]string{"-port", "5000"}))
+
+	r.Equal("db.example.com", *host)
+	r.Equal(5000, *port)
+	r.Equal(5*time.Second, *timeout)
+	r.False(*debug)
+}
+
+func Test_Env_BindFlagSet_Invalid(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"MYAPP_PORT": "nope"})
+	fs := flag.NewFlagSet("myapp", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Int("port", 3000, "")
+
+	err := e.BindFlagSet(fs, "MYAPP")
+	r.Error(err)
+	r.Contains(err.Error(), `invalid value "nope" for flag -port from MYAPP_PORT`)
+}
+
+func Test_Env_BindFlags(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"MYAPP_DB_HOST": "db.example.com",
+		"MYAPP_PORT":    "4000",
+	})
+
+	values := map[string]string{}
+	changed := map[string]bool{"port": true}
+	bound, err := e.BindFlags("MYAPP", []string{"db-host", "port", "debug"}, func(name string) bool {
+		return changed[name]
+	}, func(name, value string) error {
+		values[name] = value
+		return nil
+	})
+	r.NoError(err)
+	r.Equal(map[string]string{"db-host": "db.example.com"}, values)
+	r.Equal(map[string]string{"db-host": "MYAPP_DB_HOST"}, bound)
+}
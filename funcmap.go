@@ -0,0 +1,50 @@
+package envy
+
+import (
+	"fmt"
+	"strconv"
+	"text/template"
+)
+
+// FuncMap returns template functions reading the Env:
+//
+//	env "KEY"               the value of KEY, or an empty string
+//	envOr "KEY" "default"   the value of KEY, or default when it isn't set
+//	requiredEnv "KEY"       the value of KEY, failing when it's empty
+//	envBool "KEY"           KEY parsed as a bool, false when it isn't set
+//
+// The FuncMap can be used with html/template too, once converted
+// to an html/template.FuncMap.
+func (e *Env) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"env": func(key string) string {
+			return e.Get(key, "")
+		},
+		"envOr": func(key, def string) string {
+			return e.Get(key, def)
+		},
+		"requiredEnv": func(key string) (string, error) {
+			v, _ := e.Lookup(key)
+			if v == "" {
+				return "", fmt.Errorf("required ENV var %s is not set", key)
+			}
+			return v, nil
+		},
+		"envBool": func(key string) (bool, error) {
+			v, ok := e.Lookup(key)
+			if !ok || v == "" {
+				return false, nil
+			}
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return false, fmt.Errorf("ENV var %s is not a bool: %q", key, v)
+			}
+			return b, nil
+		},
+	}
+}
+
+// FuncMap returns template functions reading envy.
+func FuncMap() template.FuncMap {
+	return env.FuncMap()
+}
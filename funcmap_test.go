@@ -0,0 +1,42 @@
+package envy
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_FuncMap(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"APP":   "coke",
+		"DEBUG": "true",
+		"BAD":   "maybe",
+		"EMPTY": "",
+	})
+
+	render := func(s string) (string, error) {
+		tmpl, err := template.New("t").Funcs(e.FuncMap()).Parse(s)
+		if err != nil {
+			return "", err
+		}
+		bb := &bytes.Buffer{}
+		err = tmpl.Execute(bb, nil)
+		return bb.String(), err
+	}
+
+	s, err := render(`{{env "APP"}} {{env "NOPE"}}|{{envOr "NOPE" "def"}} {{envOr "EMPTY" "def"}}|{{requiredEnv "APP"}}|{{envBool "DEBUG"}} {{envBool "NOPE"}}`)
+	r.NoError(err)
+	r.Equal("coke |def |coke|true false", s)
+
+	_, err = render(`{{requiredEnv "EMPTY"}}`)
+	r.Error(err)
+	r.Contains(err.Error(), "required ENV var EMPTY is not set")
+
+	_, err = render(`{{envBool "BAD"}}`)
+	r.Error(err)
+	r.Contains(err.Error(), `ENV var BAD is not a bool: "maybe"`)
+}
@@ -1,6 +1,6 @@
 module github.com/gobuffalo/envy
 
-go 1.16
+go 1.18
 
 exclude github.com/stretchr/testify v1.7.1
 
@@ -8,4 +8,12 @@ require (
 	github.com/joho/godotenv v1.4.0
 	github.com/rogpeppe/go-internal v1.9.0
 	github.com/stretchr/testify v1.8.0
+	golang.org/x/sys v0.13.0
+	golang.org/x/term v0.13.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 )
@@ -0,0 +1,121 @@
+package envy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GoFlags returns the flags in GOFLAGS, the flags the go tool
+// applies to every command. Quoted flags, like the go tool
+// accepts them, may hold spaces: GOFLAGS='-ldflags=-s -w' -mod=mod.
+func (e *Env) GoFlags() []string {
+	return splitGoFlags(e.Get("GOFLAGS", ""))
+}
+
+// GoFlags returns the flags in GOFLAGS.
+func GoFlags() []string {
+	return env.GoFlags()
+}
+
+// SetGoFlag sets flag, such as -mod=vendor, in GOFLAGS, with MustSet,
+// so it applies to the go commands run by the process. A flag of the
+// same name already in GOFLAGS, such as -mod=mod, is replaced, as the
+// go tool rejects flags given more than once.
+func (e *Env) SetGoFlag(flag string) error {
+	name := goFlagName(flag)
+	if name == "" {
+		return fmt.Errorf("invalid go flag %q", flag)
+	}
+	flag = "-" + strings.TrimLeft(flag, "-")
+
+	flags := e.GoFlags()
+	replaced := false
+	for i, f := range flags {
+		if goFlagName(f) == name {
+			flags[i] = flag
+			replaced = true
+		}
+	}
+	if !replaced {
+		flags = append(flags, flag)
+	}
+	return e.MustSet("GOFLAGS", joinGoFlags(dedupGoFlags(flags)))
+}
+
+// SetGoFlag sets flag in GOFLAGS, replacing the flag of the same name.
+func SetGoFlag(flag string) error {
+	return env.SetGoFlag(flag)
+}
+
+// goFlagName returns the name of flag, without its dashes or value.
+func goFlagName(flag string) string {
+	name := strings.TrimLeft(flag, "-")
+	if i := strings.IndexByte(name, '='); i != -1 {
+		name = name[:i]
+	}
+	return name
+}
+
+// dedupGoFlags removes the repeated flags, keeping the first one.
+func dedupGoFlags(flags []string) []string {
+	seen := map[string]bool{}
+	x := flags[:0]
+	for _, f := range flags {
+		n := goFlagName(f)
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		x = append(x, f)
+	}
+	return x
+}
+
+// splitGoFlags splits s on spaces, outside of single or double quotes.
+func splitGoFlags(s string) []string {
+	var flags []string
+	var quote byte
+	bb := &strings.Builder{}
+	in := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			bb.WriteByte(c)
+		case c == '\'' || c == '"':
+			quote = c
+			in = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if in {
+				flags = append(flags, bb.String())
+				bb.Reset()
+				in = false
+			}
+		default:
+			bb.WriteByte(c)
+			in = true
+		}
+	}
+	if in {
+		flags = append(flags, bb.String())
+	}
+	return flags
+}
+
+// joinGoFlags joins flags with spaces, quoting those holding spaces.
+func joinGoFlags(flags []string) string {
+	x := make([]string, len(flags))
+	for i, f := range flags {
+		switch {
+		case !strings.ContainsAny(f, " \t\n\r'\""):
+			x[i] = f
+		case !strings.Contains(f, "'"):
+			x[i] = "'" + f + "'"
+		default:
+			x[i] = `"` + f + `"`
+		}
+	}
+	return strings.Join(x, " ")
+}
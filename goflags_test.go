@@ -0,0 +1,38 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_GoFlags(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"GOFLAGS": `-mod=mod  '-ldflags=-s -w' -tags=a,b`})
+	r.Equal([]string{"-mod=mod", "-ldflags=-s -w", "-tags=a,b"}, e.GoFlags())
+
+	e.Set("GOFLAGS", "")
+	r.Empty(e.GoFlags())
+}
+
+func Test_Env_SetGoFlag(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"GOFLAGS": `-mod=mod '-ldflags=-s -w'`})
+	r.NoError(e.SetGoFlag("-mod=vendor"))
+	r.Equal(`-mod=vendor '-ldflags=-s -w'`, e.Get("GOFLAGS", ""))
+
+	r.NoError(e.SetGoFlag("--tags=integration"))
+	r.Equal(`-mod=vendor '-ldflags=-s -w' -tags=integration`, e.Get("GOFLAGS", ""))
+
+	r.NoError(e.SetGoFlag("-trimpath"))
+	r.Equal([]string{"-mod=vendor", "-ldflags=-s -w", "-tags=integration", "-trimpath"}, e.GoFlags())
+
+	e.Set("GOFLAGS", "-mod=mod -mod=readonly")
+	r.NoError(e.SetGoFlag("-mod=vendor"))
+	r.Equal("-mod=vendor", e.Get("GOFLAGS", ""))
+
+	r.Error(e.SetGoFlag("--"))
+	r.Error(e.SetGoFlag("=x"))
+}
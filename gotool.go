@@ -0,0 +1,320 @@
+package envy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var errNoGoTool = errors.New("the go tool is disabled")
+
+// goTool caches what was learned by running the go tool,
+// by the go binary, GO_BIN, it was learned from.
+type goTool struct {
+	moot sync.Mutex
+	envs map[string]*goEnvResult
+}
+
+// goEnvResult is what "go env -json" reported.
+type goEnvResult struct {
+	once sync.Once
+	env  map[string]string
+	err  error
+}
+
+// goEnv returns the variables reported by "go env -json", running it
+// the first time it's called, with the variables of the Env, so it
+// reflects the values set on it at the time.
+func (e *Env) goEnv() (map[string]string, error) {
+	if !e.gotool {
+		return nil, errNoGoTool
+	}
+	bin := e.Get("GO_BIN", "go")
+	return e.tool.loadEnv(bin, func() *exec.Cmd {
+		cmd := exec.Command(bin, "env", "-json")
+		cmd.Env = e.Environ()
+		return cmd
+	})
+}
+
+// loadEnv runs the command returned by cmd, "go env -json" with the
+// go binary bin, the first time it's called for bin, and returns the
+// variables it reported.
+func (t *goTool) loadEnv(bin string, cmd func() *exec.Cmd) (map[string]string, error) {
+	t.moot.Lock()
+	if t.envs == nil {
+		t.envs = map[string]*goEnvResult{}
+	}
+	r, ok := t.envs[bin]
+	if !ok {
+		r = &goEnvResult{}
+		t.envs[bin] = r
+	}
+	t.moot.Unlock()
+
+	r.once.Do(func() {
+		out, err := cmd().Output()
+		if err != nil {
+			r.err = fmt.Errorf("could not run go env: %w", err)
+			return
+		}
+		m := map[string]string{}
+		if err := json.Unmarshal(out, &m); err != nil {
+			r.err = fmt.Errorf("could not parse go env: %w", err)
+			return
+		}
+		r.env = m
+	})
+	return r.env, r.err
+}
+
+// GoEnv returns the variables reported by "go env -json", such as
+// GOPATH, GOMOD, and GOROOT. The go tool, GO_BIN, is only run once,
+// the first time they're needed, and what it reported is cached, so
+// values set on the Env, other than GO_BIN, or a change of the current
+// directory, later on aren't reflected. The returned map is a copy.
+func (e *Env) GoEnv() (map[string]string, error) {
+	m, err := e.goEnv()
+	if err != nil {
+		return nil, err
+	}
+	return copyVars(m), nil
+}
+
+// GoEnv returns the variables reported by "go env -json".
+func GoEnv() (map[string]string, error) {
+	return env.GoEnv()
+}
+
+// GoMod returns the path of the go.mod of the main module, as
+// reported by "go env GOMOD": the empty string outside of a module,
+// or os.DevNull when modules are disabled, and when the go tool
+// can't be run.
+func (e *Env) GoMod() string {
+	m, err := e.goEnv()
+	if err != nil {
+		return ""
+	}
+	return m["GOMOD"]
+}
+
+// GoMod returns the path of the go.mod of the main module.
+func GoMod() string {
+	return env.GoMod()
+}
+
+// GoRoot returns the root of the Go toolchain the go tool runs, as
+// reported by "go env", which accounts for the toolchain switching of
+// Go 1.21 and later. When the go tool can't be run, the GOROOT
+// variable, or the root the program was built with, is returned.
+func (e *Env) GoRoot() string {
+	if m, err := e.goEnv(); err == nil && m["GOROOT"] != "" {
+		return m["GOROOT"]
+	}
+	if gr := e.Get("GOROOT", ""); gr != "" {
+		return gr
+	}
+	return runtime.GOROOT()
+}
+
+// GoRoot returns the root of the Go toolchain the go tool runs.
+func GoRoot() string {
+	return env.GoRoot()
+}
+
+// GoToolchain returns the GOTOOLCHAIN setting of the go tool, such as
+// "auto", "local", or "go1.22.1+auto", which controls whether it
+// switches to the toolchain a go.mod requires, as reported by "go
+// env". When the go tool can't be run, the GOTOOLCHAIN variable is
+// returned, defaulting to "auto", like the go tool does.
+func (e *Env) GoToolchain() string {
+	if m, err := e.goEnv(); err == nil && m["GOTOOLCHAIN"] != "" {
+		return m["GOTOOLCHAIN"]
+	}
+	return e.Get("GOTOOLCHAIN", "auto")
+}
+
+// GoToolchain returns the GOTOOLCHAIN setting of the go tool.
+func GoToolchain() string {
+	return env.GoToolchain()
+}
+
+// GoVersion returns the version of the go tool, such as "go1.22.1",
+// its GOVERSION as reported by GoEnv. When the go tool can't, or may
+// not, see WithGoTool, be run, or doesn't report it, the version of
+// Go the program was built with is returned instead.
+func (e *Env) GoVersion() (string, error) {
+	if !e.gotool {
+		return runtime.Version(), nil
+	}
+	m, err := e.goEnv()
+	if err == nil {
+		// go1.22.1, possibly followed by the experiments, X:...
+		if f := strings.Fields(m["GOVERSION"]); len(f) > 0 && strings.HasPrefix(f[0], "go") {
+			return f[0], nil
+		}
+		err = errors.New("go env reported no GOVERSION")
+	}
+	if v := runtime.Version(); strings.HasPrefix(v, "go") {
+		return v, nil
+	}
+	return "", fmt.Errorf("could not determine the go version: %w", err)
+}
+
+// GoVersion returns the version of the go tool.
+func GoVersion() (string, error) {
+	return env.GoVersion()
+}
+
+// AtLeast reports whether the version of the go tool, see GoVersion,
+// is at least v, such as "1.22" or "go1.21.3". Like the go tool, it
+// considers 1.21 to come before 1.21rc1, which comes before 1.21.0.
+// It returns false when the version is unknown, or v is invalid.
+func (e *Env) AtLeast(v string) bool {
+	gv, err := e.GoVersion()
+	if err != nil {
+		return false
+	}
+	c, err := CompareGoVersions(gv, v)
+	return err == nil && c >= 0
+}
+
+// AtLeast reports whether the version of the go tool is at least v.
+func AtLeast(v string) bool {
+	return env.AtLeast(v)
+}
+
+// CompareGoVersions compares the Go versions a and b, such as
+// "go1.21.3" and "1.22rc1", returning -1, 0, or +1, like the
+// go tool orders them.
+func CompareGoVersions(a, b string) (int, error) {
+	va, err := parseGoVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseGoVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range va {
+		switch {
+		case va[i] < vb[i]:
+			return -1, nil
+		case va[i] > vb[i]:
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// goVersion ranks, after the minor version, a language version, such
+// as 1.21, before the pre-releases, which come before the releases.
+const (
+	goLang = iota
+	goAlpha
+	goBeta
+	goRC
+	goRelease
+)
+
+// parseGoVersion returns major, minor, rank, and then the
+// pre-release number or the patch version, of v.
+func parseGoVersion(v string) ([4]int, error) {
+	var x [4]int
+	s := strings.TrimPrefix(v, "go")
+	bad := fmt.Errorf("invalid go version %q", v)
+
+	x[2] = goLang
+	for _, p := range []struct {
+		kind string
+		rank int
+	}{{"alpha", goAlpha}, {"beta", goBeta}, {"rc", goRC}} {
+		if i := strings.Index(s, p.kind); i != -1 {
+			n, err := strconv.Atoi(s[i+len(p.kind):])
+			if err != nil {
+				return x, bad
+			}
+			x[2], x[3] = p.rank, n
+			s = s[:i]
+			break
+		}
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 || (len(parts) == 3 && x[2] != goLang) {
+		return x, bad
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return x, bad
+		}
+		switch i {
+		case 0, 1:
+			x[i] = n
+		case 2:
+			x[2], x[3] = goRelease, n
+		}
+	}
+	return x, nil
+}
+
+// GoCache returns the directory of the build cache of the go tool,
+// as reported by "go env". When the go tool can't be run, it's the
+// GOCACHE variable, or, like the go tool computes it, the go-build
+// directory of the user's cache directory; the empty string when
+// that can't be determined either.
+func (e *Env) GoCache() string {
+	if m, err := e.goEnv(); err == nil && m["GOCACHE"] != "" {
+		return m["GOCACHE"]
+	}
+	if gc := e.Get("GOCACHE", ""); gc != "" {
+		return gc
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "go-build")
+}
+
+// GoCache returns the directory of the build cache of the go tool.
+func GoCache() string {
+	return env.GoCache()
+}
+
+// GoModCache returns the directory of the module cache of the go
+// tool, as reported by "go env". When the go tool can't be run, it's
+// the GOMODCACHE variable, or, like the go tool computes it, the
+// pkg/mod directory of the first GOPATH, which defaults to the go
+// directory of the user's home; the empty string when that can't be
+// determined either.
+func (e *Env) GoModCache() string {
+	if m, err := e.goEnv(); err == nil && m["GOMODCACHE"] != "" {
+		return m["GOMODCACHE"]
+	}
+	if mc := e.Get("GOMODCACHE", ""); mc != "" {
+		return mc
+	}
+	gp := filepath.SplitList(e.Get("GOPATH", ""))
+	if len(gp) == 0 || gp[0] == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		gp = []string{filepath.Join(home, "go")}
+	}
+	return filepath.Join(gp[0], "pkg", "mod")
+}
+
+// GoModCache returns the directory of the module cache of the go tool.
+func GoModCache() string {
+	return env.GoModCache()
+}
@@ -0,0 +1,170 @@
+package envy
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_GoVersion(t *testing.T) {
+	r := require.New(t)
+
+	e := New()
+	v, err := e.GoVersion()
+	r.NoError(err)
+	r.True(strings.HasPrefix(v, "go1."), v)
+
+	// cached
+	e.Set("GO_BIN", "not-a-go-binary")
+	v2, err := e.GoVersion()
+	r.NoError(err)
+	r.Equal(v, v2)
+
+	r.True(e.AtLeast("1.16"))
+	r.False(e.AtLeast("99.0"))
+	r.False(e.AtLeast("nope"))
+}
+
+func Test_CompareGoVersions(t *testing.T) {
+	r := require.New(t)
+
+	ordered := []string{"1.20", "go1.20.1", "1.21", "1.21rc1", "go1.21rc2", "1.21.0", "1.21.3", "1.22", "1.22beta1"}
+	for i := 0; i < len(ordered)-1; i++ {
+		c, err := CompareGoVersions(ordered[i], ordered[i+1])
+		r.NoError(err)
+		r.Equal(-1, c, "%s < %s", ordered[i], ordered[i+1])
+
+		c, err = CompareGoVersions(ordered[i+1], ordered[i])
+		r.NoError(err)
+		r.Equal(1, c, "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	c, err := CompareGoVersions("go1.21.0", "1.21.0")
+	r.NoError(err)
+	r.Equal(0, c)
+
+	for _, bad := range []string{"", "go", "1.x", "1.21.0rc1", "1.2.3.4", "1.21rc"} {
+		_, err := CompareGoVersions(bad, "1.21")
+		r.Error(err, bad)
+	}
+}
+
+func Test_Env_GoRoot(t *testing.T) {
+	r := require.New(t)
+
+	e := New()
+	gr := e.GoRoot()
+	r.NotEmpty(gr)
+	_, err := os.Stat(filepath.Join(gr, "src", "runtime"))
+	r.NoError(err)
+
+	e = New()
+	e.Set("GO_BIN", "not-a-go-binary")
+	e.Set("GOROOT", "/usr/local/go")
+	r.Equal("/usr/local/go", e.GoRoot())
+}
+
+func Test_Env_GoToolchain(t *testing.T) {
+	r := require.New(t)
+
+	e := New()
+	e.Set("GOTOOLCHAIN", "local")
+	r.Equal("local", e.GoToolchain())
+
+	e = New()
+	e.Set("GO_BIN", "not-a-go-binary")
+	e.Unset("GOTOOLCHAIN")
+	r.Equal("auto", e.GoToolchain())
+}
+
+func Test_Env_GoCache(t *testing.T) {
+	r := require.New(t)
+
+	out, err := exec.Command("go", "env", "GOCACHE").Output()
+	r.NoError(err)
+	r.Equal(strings.TrimSpace(string(out)), New().GoCache())
+
+	e := FromMap(map[string]string{"GO_BIN": "not-a-go-binary", "GOCACHE": "/tmp/gocache"})
+	r.Equal("/tmp/gocache", e.GoCache())
+
+	e.Unset("GOCACHE")
+	dir, err := os.UserCacheDir()
+	r.NoError(err)
+	r.Equal(filepath.Join(dir, "go-build"), e.GoCache())
+}
+
+func Test_Env_GoModCache(t *testing.T) {
+	r := require.New(t)
+
+	out, err := exec.Command("go", "env", "GOMODCACHE").Output()
+	r.NoError(err)
+	r.Equal(strings.TrimSpace(string(out)), New().GoModCache())
+
+	e := FromMap(map[string]string{"GO_BIN": "not-a-go-binary", "GOMODCACHE": "/tmp/modcache"})
+	r.Equal("/tmp/modcache", e.GoModCache())
+
+	e.Unset("GOMODCACHE")
+	e.Set("GOPATH", strings.Join([]string{"/foo", "/bar"}, string(filepath.ListSeparator)))
+	r.Equal(filepath.Join("/foo", "pkg", "mod"), e.GoModCache())
+
+	e.Unset("GOPATH")
+	home, err := os.UserHomeDir()
+	r.NoError(err)
+	r.Equal(filepath.Join(home, "go", "pkg", "mod"), e.GoModCache())
+}
+
+func Test_Env_GoEnv(t *testing.T) {
+	r := require.New(t)
+
+	e := New()
+	m, err := e.GoEnv()
+	r.NoError(err)
+	r.NotEmpty(m["GOROOT"])
+	r.Equal(m["GOROOT"], e.GoRoot())
+
+	// a copy
+	m["GOROOT"] = "nope"
+	m2, err := e.GoEnv()
+	r.NoError(err)
+	r.NotEqual("nope", m2["GOROOT"])
+
+	pwd, err := os.Getwd()
+	r.NoError(err)
+	r.Equal(filepath.Join(pwd, "go.mod"), e.GoMod())
+}
+
+func Test_Env_GoEnv_Error(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"GO_BIN": "not-a-go-binary"})
+	_, err := e.GoEnv()
+	r.Error(err)
+	r.Equal("", e.GoMod())
+}
+
+func Test_WithGoTool(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithGoTool(false))
+	e.Set("GO_BIN", filepath.Join(t.TempDir(), "go"))
+	e.Set("GOROOT", "/opt/go")
+	e.Set("GOMODCACHE", "/opt/mod")
+
+	_, err := e.GoEnv()
+	r.EqualError(err, "the go tool is disabled")
+	r.Equal("", e.GoMod())
+	r.Equal("/opt/go", e.GoRoot())
+	r.Equal("/opt/mod", e.GoModCache())
+	r.Equal("auto", e.GoToolchain())
+
+	v, err := e.GoVersion()
+	r.NoError(err)
+	r.Equal(runtime.Version(), v)
+
+	r.False(e.Clone().gotool)
+}
@@ -0,0 +1,74 @@
+package envy
+
+import "time"
+
+// HistoryEntry is a change made to an Env, see WithHistory.
+type HistoryEntry struct {
+	Change
+	// Time the change was made at.
+	Time time.Time `json:"time"`
+	// Source of the new value, such as "set", or the path of a
+	// .env file, or "os" for the changes found by Reload.
+	Source string `json:"source"`
+	// Caller that made the change, as "file:line".
+	Caller string `json:"caller"`
+}
+
+// WithHistory keeps the n most recent changes made to the Env, the
+// ones OnChange hooks are called with, so when LOG_LEVEL flipped to
+// debug, and who flipped it, is answerable at runtime, see History.
+// It's off, keeping none, by default.
+func WithHistory(n int) Option {
+	return func(e *Env) {
+		e.historySize = n
+	}
+}
+
+// History returns the most recent changes made to the Env, oldest
+// first, as many as configured with WithHistory. The values of the
+// secrets are redacted.
+func (e *Env) History() []HistoryEntry {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	h := make([]HistoryEntry, len(e.history))
+	copy(h, e.history)
+	for i, he := range h {
+		if !e.isSecret(he.Key) {
+			continue
+		}
+		if he.Old != "" {
+			h[i].Old = Redacted
+		}
+		if he.New != "" {
+			h[i].New = Redacted
+		}
+	}
+	return h
+}
+
+// History returns the most recent changes made to envy.
+func History() []HistoryEntry {
+	return env.History()
+}
+
+// remember adds the changes to the history of the Env, when
+// it keeps one. It must be called with e.moot held.
+func (e *Env) remember(changes []Change) {
+	if e.historySize <= 0 || len(changes) == 0 {
+		return
+	}
+	now, at := time.Now(), caller()
+	for _, c := range changes {
+		source, ok := e.sources[c.Key]
+		if !ok {
+			source = "os"
+		}
+		if c.Op == Removed {
+			source = ""
+		}
+		if len(e.history) == e.historySize {
+			e.history = append(e.history[:0], e.history[1:]...)
+		}
+		e.history = append(e.history, HistoryEntry{Change: c, Time: now, Source: source, Caller: at})
+	}
+}
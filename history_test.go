@@ -0,0 +1,35 @@
+package envy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_History(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithHistory(3))
+	e.Set("LOG_LEVEL", "info")
+	e.Set("LOG_LEVEL", "info")
+	e.Set("LOG_LEVEL", "debug")
+
+	f := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(f, []byte("API_TOKEN=abc\nLOG_LEVEL=warn\n"), 0644))
+	r.NoError(e.Load(f))
+
+	h := e.History()
+	r.Len(h, 3)
+	r.Equal(Change{Op: Changed, Key: "LOG_LEVEL", Old: "info", New: "debug"}, h[0].Change)
+	r.Equal("set", h[0].Source)
+	r.Contains(h[0].Caller, "history_test.go:")
+	r.False(h[0].Time.IsZero())
+
+	r.Equal(Change{Op: Added, Key: "API_TOKEN", New: Redacted}, h[1].Change)
+	r.Equal(f, h[1].Source)
+	r.Equal(Change{Op: Changed, Key: "LOG_LEVEL", Old: "debug", New: "warn"}, h[2].Change)
+
+	r.Empty(New(WithOSEnv(false)).History())
+}
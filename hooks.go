@@ -0,0 +1,92 @@
+package envy
+
+import (
+	"fmt"
+	"path"
+)
+
+// SetHook validates, and possibly transforms, a value written with
+// MustSet, returning the value to write or an error rejecting it.
+type SetHook func(key, value string) (string, error)
+
+// OnSet registers a hook run by MustSet before anything is written,
+// to the Env or the OS. Hooks run in the order they were registered,
+// each one receiving the value returned by the previous one.
+func (e *Env) OnSet(fn SetHook) {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.setHooks = append(e.setHooks, fn)
+}
+
+// OnSet registers a hook run by MustSet before
+// anything is written, to envy or the OS.
+func OnSet(fn SetHook) {
+	env.OnSet(fn)
+}
+
+// OnChange registers fn to be called with every change made to the
+// Env by Set, MustSet, Load, and Reload, after the change is visible.
+// Hooks are called synchronously, and must be fast.
+func (e *Env) OnChange(fn func(Change)) {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.changeHooks = append(e.changeHooks, fn)
+}
+
+// OnChange registers fn to be called with
+// every change made to envy.
+func OnChange(fn func(Change)) {
+	env.OnChange(fn)
+}
+
+func (e *Env) runSetHooks(key, value string) (string, error) {
+	e.moot.RLock()
+	hooks := e.setHooks
+	e.moot.RUnlock()
+
+	for _, fn := range hooks {
+		v, err := fn(key, value)
+		if err != nil {
+			return "", fmt.Errorf("could not set %s: %w", key, err)
+		}
+		value = v
+	}
+	return value, nil
+}
+
+// changed returns the calls to the change and rotate hooks for the
+// changes between old and new, adding them to the history, and drops
+// the cached derived values. It must be called with e.moot held, and
+// the calls made once it's released.
+func (e *Env) changed(old, new map[string]string) []func() {
+	e.invalidateDerived()
+	if len(e.changeHooks) == 0 && len(e.rotateHooks) == 0 && e.historySize <= 0 {
+		return nil
+	}
+	changes := Diff(old, new)
+	e.remember(changes)
+	var calls []func()
+	for _, c := range changes {
+		c := c
+		for _, fn := range e.changeHooks {
+			fn := fn
+			calls = append(calls, func() { fn(c) })
+		}
+		if c.Op != Changed || !e.isSecret(c.Key) {
+			continue
+		}
+		for _, h := range e.rotateHooks {
+			if ok, _ := path.Match(h.pattern, c.Key); ok {
+				fn := h.fn
+				calls = append(calls, func() { fn(c.Key, c.Old, c.New) })
+			}
+		}
+	}
+	return calls
+}
+
+func runAll(calls []func()) {
+	for _, fn := range calls {
+		fn()
+	}
+}
@@ -0,0 +1,55 @@
+package envy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_OnSet(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"PORT": "3000"})
+	e.OnSet(func(key, value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	})
+	e.OnSet(func(key, value string) (string, error) {
+		if key == "PORT" && value == "" {
+			return "", errors.New("must not be empty")
+		}
+		return value, nil
+	})
+
+	r.NoError(e.MustSet("PORT", " 4000 "))
+	r.Equal("4000", e.Get("PORT", ""))
+
+	err := e.MustSet("PORT", "  ")
+	r.EqualError(err, "could not set PORT: must not be empty")
+	r.Equal("4000", e.Get("PORT", ""))
+
+	// Set doesn't run the hooks
+	e.Set("PORT", " 5000 ")
+	r.Equal(" 5000 ", e.Get("PORT", ""))
+}
+
+func Test_Env_OnChange(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"PORT": "3000"})
+	var changes []Change
+	e.OnChange(func(c Change) {
+		changes = append(changes, c)
+	})
+
+	r.NoError(e.MustSet("PORT", "4000"))
+	r.NoError(e.MustSet("PORT", "4000"))
+	e.Set("HOST", "localhost")
+	r.NoError(e.Load("test_env/.env"))
+
+	r.Equal(Change{Op: Changed, Key: "PORT", Old: "3000", New: "4000"}, changes[0])
+	r.Equal(Change{Op: Added, Key: "HOST", New: "localhost"}, changes[1])
+	r.Len(changes, 5)
+	r.Equal(Change{Op: Added, Key: "DIR", New: "test_env"}, changes[2])
+}
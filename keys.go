@@ -0,0 +1,101 @@
+package envy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidKey checks key follows the POSIX rules for the names of
+// environment variables, which every shell accepts: letters, digits,
+// and underscores, not starting with a digit.
+func ValidKey(key string) error {
+	return keyError(key, checkKey(key, false))
+}
+
+// ValidRelaxedKey checks key can be set in the environment of a
+// process, which only requires it isn't empty, and holds neither
+// "=" nor NUL. Such keys may not be usable from a shell.
+func ValidRelaxedKey(key string) error {
+	return keyError(key, checkKey(key, true))
+}
+
+// WithRelaxedKeys controls whether the keys of the files the Env
+// loads only have to be valid for ValidRelaxedKey, rather than for
+// ValidKey, such as the keys with dots or dashes of files written
+// for tools other than shells. An ErrParse is returned for the
+// invalid keys either way.
+func WithRelaxedKeys(b bool) Option {
+	return func(e *Env) {
+		e.relaxedKeys = b
+	}
+}
+
+// checkKey checks key, like ValidRelaxedKey when relaxed, or else
+// like ValidKey, without naming it in the error.
+func checkKey(key string, relaxed bool) error {
+	switch {
+	case key == "":
+		return errors.New("key is empty")
+	case relaxed && strings.ContainsAny(key, "=\x00"):
+		return errors.New("= and NUL aren't allowed")
+	case !relaxed && !isVarName(key):
+		return errors.New("only letters, digits, and _ are allowed, and it can't start with a digit")
+	}
+	return nil
+}
+
+// keyError names key in err, the error of checkKey.
+func keyError(key string, err error) error {
+	if err == nil || key == "" {
+		return err
+	}
+	return fmt.Errorf("invalid key %q: %w", key, err)
+}
+
+// checkKeys checks the keys of m, read from source, are valid, see
+// WithRelaxedKeys, returning an ErrParse for the first invalid one.
+func checkKeys(source string, m map[string]string, relaxed bool) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := checkKey(k, relaxed); err != nil {
+			return ErrParse{File: source, Key: k, Err: fmt.Errorf("invalid key: %w", err)}
+		}
+	}
+	return nil
+}
+
+// SanitizeKey turns key into a valid key, see ValidKey, replacing
+// every other character with _, and prefixing it with _ when it
+// starts with a digit: "db.host" becomes "db_host", and "2fa" "_2fa".
+// An empty key is returned unchanged.
+func SanitizeKey(key string) string {
+	if key == "" {
+		return key
+	}
+	bb := &strings.Builder{}
+	if '0' <= key[0] && key[0] <= '9' {
+		bb.WriteByte('_')
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+			bb.WriteByte(c)
+		case c >= 0x80:
+			// a multi-byte rune is replaced once
+			for i+1 < len(key) && key[i+1]&0xc0 == 0x80 {
+				i++
+			}
+			bb.WriteByte('_')
+		default:
+			bb.WriteByte('_')
+		}
+	}
+	return bb.String()
+}
@@ -0,0 +1,77 @@
+package envy
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidKey(t *testing.T) {
+	r := require.New(t)
+
+	for _, k := range []string{"A", "_", "db_host", "PORT_8080"} {
+		r.NoError(ValidKey(k), k)
+		r.NoError(ValidRelaxedKey(k), k)
+	}
+	for _, k := range []string{"db.host", "log-level", "2FA", "A B", "ÄPFEL"} {
+		r.Error(ValidKey(k), k)
+		r.NoError(ValidRelaxedKey(k), k)
+	}
+	for _, k := range []string{"", "A=B", "A\x00"} {
+		r.Error(ValidKey(k), k)
+		r.Error(ValidRelaxedKey(k), k)
+	}
+	r.EqualError(ValidKey(""), "key is empty")
+}
+
+func Test_SanitizeKey(t *testing.T) {
+	r := require.New(t)
+
+	table := map[string]string{
+		"":          "",
+		"PORT":      "PORT",
+		"db.host":   "db_host",
+		"log-level": "log_level",
+		"2fa":       "_2fa",
+		"A=B":       "A_B",
+		"ÄPFEL":     "_PFEL",
+	}
+	for in, out := range table {
+		r.Equal(out, SanitizeKey(in), in)
+		if out != "" {
+			r.NoError(ValidKey(out))
+		}
+	}
+}
+
+func Test_Env_Load_InvalidKeys(t *testing.T) {
+	r := require.New(t)
+
+	path := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(path, []byte("PORT=3000\nlog-level=debug\n"), 0600))
+
+	for _, l := range []Loader{GodotenvLoader{}, NativeLoader{}} {
+		e := New(WithOSEnv(false), WithLoader(l))
+		err := e.Load(path)
+		r.EqualError(err, path+": log-level: invalid key: only letters, digits, and _ are allowed, and it can't start with a digit")
+		var pe ErrParse
+		r.True(errors.As(err, &pe))
+		r.Equal(path, pe.File)
+		r.Equal("log-level", pe.Key)
+		r.True(errors.Is(err, ErrParse{File: path}))
+		_, ok := e.Lookup("PORT")
+		r.False(ok)
+
+		e = New(WithOSEnv(false), WithLoader(l), WithRelaxedKeys(true))
+		r.NoError(e.Load(path))
+		r.Equal("debug", e.Get("log-level", ""))
+	}
+
+	// settings are sanitized, see SanitizeKey
+	e := New(WithOSEnv(false))
+	e.LoadSettings(settings{"log-level": "debug"})
+	r.Equal("debug", e.Get("LOG_LEVEL", ""))
+}
@@ -0,0 +1,105 @@
+package envy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedAnnotation lists, in the manifests generated with
+// KubernetesOptions.AnnotateRedacted, the keys whose values
+// were redacted.
+const RedactedAnnotation = "envy.gobuffalo.io/redacted"
+
+// KubernetesOptions controls the manifests generated by
+// ToKubernetesConfigMap and ToKubernetesSecret.
+type KubernetesOptions struct {
+	ExportOptions
+	// Labels added to the metadata of the manifest.
+	Labels map[string]string
+	// AnnotateRedacted lists the keys whose values were redacted in
+	// the RedactedAnnotation annotation, so the manifest can be
+	// completed by the deployment pipeline.
+	AnnotateRedacted bool
+}
+
+type k8sMetadata struct {
+	Name        string            `yaml:"name"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+type k8sManifest struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	Data       map[string]string `yaml:"data"`
+}
+
+// ToKubernetesConfigMap returns the Env as the YAML manifest of a
+// Kubernetes ConfigMap with the given name and namespace. The
+// namespace is left out when empty.
+func (e *Env) ToKubernetesConfigMap(name, namespace string, opts KubernetesOptions) ([]byte, error) {
+	return e.k8sManifest("ConfigMap", name, namespace, opts, func(v string) string {
+		return v
+	})
+}
+
+// ToKubernetesSecret returns the Env as the YAML manifest of an Opaque
+// Kubernetes Secret with the given name and namespace, its values
+// base64 encoded. The namespace is left out when empty.
+func (e *Env) ToKubernetesSecret(name, namespace string, opts KubernetesOptions) ([]byte, error) {
+	return e.k8sManifest("Secret", name, namespace, opts, func(v string) string {
+		return base64.StdEncoding.EncodeToString([]byte(v))
+	})
+}
+
+func (e *Env) k8sManifest(kind, name, namespace string, opts KubernetesOptions, encode func(string) string) ([]byte, error) {
+	m, keys, redactedKeys, err := e.exportRedacted(opts.ExportOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	man := k8sManifest{
+		APIVersion: "v1",
+		Kind:       kind,
+		Metadata: k8sMetadata{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    opts.Labels,
+		},
+		Data: map[string]string{},
+	}
+	if kind == "Secret" {
+		man.Type = "Opaque"
+	}
+
+	var redacted []string
+	for _, k := range keys {
+		man.Data[k] = encode(m[k])
+		if redactedKeys[k] {
+			redacted = append(redacted, k)
+		}
+	}
+	if opts.AnnotateRedacted && len(redacted) > 0 {
+		sort.Strings(redacted)
+		man.Metadata.Annotations = map[string]string{
+			RedactedAnnotation: strings.Join(redacted, ","),
+		}
+	}
+	bb := &bytes.Buffer{}
+	enc := yaml.NewEncoder(bb)
+	enc.SetIndent(2)
+	if err := enc.Encode(man); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return bb.Bytes(), nil
+}
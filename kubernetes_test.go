@@ -0,0 +1,77 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_ToKubernetesConfigMap(t *testing.T) {
+	r := require.New(t)
+
+	b, err := exportEnv().ToKubernetesConfigMap("app", "prod", KubernetesOptions{
+		ExportOptions: ExportOptions{
+			Keys:   []string{"APP_*"},
+			Redact: []string{"*_TOKEN"},
+		},
+		Labels:           map[string]string{"app": "envy"},
+		AnnotateRedacted: true,
+	})
+	r.NoError(err)
+	r.Equal(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  namespace: prod
+  labels:
+    app: envy
+  annotations:
+    envy.gobuffalo.io/redacted: APP_TOKEN
+data:
+  APP_EMPTY: ""
+  APP_NAME: envy
+  APP_TOKEN: '***REDACTED***'
+`, string(b))
+}
+
+func Test_Env_ToKubernetesSecret(t *testing.T) {
+	r := require.New(t)
+
+	b, err := exportEnv().ToKubernetesSecret("app", "", KubernetesOptions{
+		ExportOptions: ExportOptions{Keys: []string{"APP_NAME", "APP_TOKEN"}},
+	})
+	r.NoError(err)
+	r.Equal(`apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+type: Opaque
+data:
+  APP_NAME: ZW52eQ==
+  APP_TOKEN: aHVudGVyMg==
+`, string(b))
+}
+
+func Test_Env_ToKubernetesConfigMap_Prefix(t *testing.T) {
+	r := require.New(t)
+
+	b, err := exportEnv().ToKubernetesConfigMap("app", "", KubernetesOptions{
+		ExportOptions: ExportOptions{
+			Keys:        []string{"APP_NAME", "APP_TOKEN"},
+			Redact:      []string{"APP_TOKEN"},
+			StripPrefix: "APP_",
+		},
+		AnnotateRedacted: true,
+	})
+	r.NoError(err)
+	r.Equal(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  annotations:
+    envy.gobuffalo.io/redacted: TOKEN
+data:
+  NAME: envy
+  TOKEN: '***REDACTED***'
+`, string(b))
+}
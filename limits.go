@@ -0,0 +1,130 @@
+package envy
+
+import "fmt"
+
+// Limits on the size of an Env, for the services that exec children
+// on platforms with strict limits on the size of the environment.
+// Zero means no limit.
+type Limits struct {
+	// MaxKeys is the maximum number of keys.
+	MaxKeys int
+	// MaxValueLen is the maximum length of a value, in bytes.
+	MaxValueLen int
+	// MaxTotalBytes is the maximum size of the environment, counting
+	// every variable as "key=value" followed by a NUL, like execve.
+	MaxTotalBytes int
+}
+
+// ErrLimitExceeded is returned when setting, or loading, a value would
+// exceed one of the Limits of the Env. errors.Is(err, ErrLimitExceeded{})
+// reports whether err is one, for any key and limit.
+type ErrLimitExceeded struct {
+	Key string
+	// Limit is the name of the field of Limits that was exceeded.
+	Limit string
+	Max   int
+	Got   int
+}
+
+func (e ErrLimitExceeded) Error() string {
+	msg := fmt.Sprintf("%s of %d exceeded: %d", e.Limit, e.Max, e.Got)
+	if e.Key == "" {
+		return msg
+	}
+	return e.Key + ": " + msg
+}
+
+// Is reports whether target is an ErrLimitExceeded for the same
+// key and limit, or for any of them when they're empty.
+func (e ErrLimitExceeded) Is(target error) bool {
+	t, ok := target.(ErrLimitExceeded)
+	return ok && (t.Key == "" || t.Key == e.Key) && (t.Limit == "" || t.Limit == e.Limit)
+}
+
+// WithLimits sets the Limits of the Env, enforced by Set, MustSet,
+// and Load, which reject the values that would exceed them, MustSet
+// and Load returning an ErrLimitExceeded, see OnLimitExceeded. The
+// values the Env already holds, such as the ones inherited from the
+// OS, aren't affected: when they already exceed MaxKeys or
+// MaxTotalBytes only the values growing the Env are rejected.
+func WithLimits(l Limits) Option {
+	return func(e *Env) {
+		e.limits = l
+	}
+}
+
+// OnLimitExceeded registers a hook called with the ErrLimitExceeded
+// every time a value is rejected as it would exceed the Limits of the
+// Env, including by Set, which doesn't return an error.
+func (e *Env) OnLimitExceeded(fn func(err error)) {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.limitHooks = append(e.limitHooks, fn)
+}
+
+// OnLimitExceeded registers a hook called every time a
+// value is rejected as it would exceed the Limits of envy.
+func OnLimitExceeded(fn func(err error)) {
+	env.OnLimitExceeded(fn)
+}
+
+// checkLimits returns an ErrLimitExceeded when setting the values of m
+// would exceed the Limits of the Env, and the calls to the hooks
+// registered with OnLimitExceeded. It must be called with e.moot held.
+func (e *Env) checkLimits(m map[string]string) ([]func(), error) {
+	if e.limits == (Limits{}) {
+		return nil, nil
+	}
+	err := e.exceeded(m)
+	if err == nil {
+		return nil, nil
+	}
+	calls := make([]func(), 0, len(e.limitHooks))
+	for _, fn := range e.limitHooks {
+		fn := fn
+		calls = append(calls, func() { fn(err) })
+	}
+	return calls, err
+}
+
+// exceeded implements checkLimits.
+func (e *Env) exceeded(m map[string]string) error {
+	l := e.limits
+	vars := e.all()
+	keys, size := len(vars), environSize(vars)
+	for k, v := range m {
+		if l.MaxValueLen > 0 && len(v) > l.MaxValueLen {
+			return ErrLimitExceeded{Key: k, Limit: "MaxValueLen", Max: l.MaxValueLen, Got: len(v)}
+		}
+		vars[e.canon(k)] = v
+	}
+	if n := len(vars); l.MaxKeys > 0 && n > l.MaxKeys && n > keys {
+		return ErrLimitExceeded{Key: onlyKey(m), Limit: "MaxKeys", Max: l.MaxKeys, Got: n}
+	}
+	if n := environSize(vars); l.MaxTotalBytes > 0 && n > l.MaxTotalBytes && n > size {
+		return ErrLimitExceeded{Key: onlyKey(m), Limit: "MaxTotalBytes", Max: l.MaxTotalBytes, Got: n}
+	}
+	return nil
+}
+
+// environSize returns the size of vars as an environment,
+// every variable as "key=value" followed by a NUL.
+func environSize(vars map[string]string) int {
+	n := 0
+	for k, v := range vars {
+		n += len(k) + len(v) + 2
+	}
+	return n
+}
+
+// onlyKey returns the key of m when it has a single one,
+// or else the empty string.
+func onlyKey(m map[string]string) string {
+	if len(m) != 1 {
+		return ""
+	}
+	for k := range m {
+		return k
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package envy
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Limits(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithLimits(Limits{MaxKeys: 2, MaxValueLen: 8, MaxTotalBytes: 14}))
+	var exceeded []error
+	e.OnLimitExceeded(func(err error) {
+		exceeded = append(exceeded, err)
+	})
+
+	e.Set("A", "1")
+	r.NoError(e.MustSet("B", "2"))
+
+	e.Set("C", "3")
+	_, ok := e.Lookup("C")
+	r.False(ok)
+
+	err := e.MustSet("A", "123456789")
+	r.Equal(ErrLimitExceeded{Key: "A", Limit: "MaxValueLen", Max: 8, Got: 9}, err)
+	r.Equal("A: MaxValueLen of 8 exceeded: 9", err.Error())
+	r.Equal("1", e.Get("A", ""))
+
+	err = e.MustSet("A", "12345678")
+	r.True(errors.Is(err, ErrLimitExceeded{Limit: "MaxTotalBytes"}))
+
+	f := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(f, []byte("D=4\nE=5\n"), 0644))
+	err = e.Load(f)
+	r.True(errors.Is(err, ErrLimitExceeded{}))
+	r.Equal(f, ErrorSource(err))
+	r.Equal(map[string]string{"A": "1", "B": "2"}, e.Map())
+
+	r.Len(exceeded, 4)
+	r.Equal(ErrLimitExceeded{Key: "C", Limit: "MaxKeys", Max: 2, Got: 3}, exceeded[0])
+}
+
+func Test_Env_Limits_Exceeded(t *testing.T) {
+	r := require.New(t)
+
+	// the values it already holds are kept, and can be replaced
+	e := FromMap(map[string]string{"A": "1", "B": "2"})
+	e.limits = Limits{MaxKeys: 1}
+	r.NoError(e.MustSet("A", "3"))
+	r.Error(e.MustSet("C", "3"))
+	r.Equal(map[string]string{"A": "3", "B": "2"}, e.Map())
+}
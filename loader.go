@@ -0,0 +1,165 @@
+package envy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+// Loader reads the variables of a .env file, or of a file in any
+// other format, for Load.
+type Loader interface {
+	Read(path string) (map[string]string, error)
+}
+
+// ContextLoader is implemented by the Loaders that can be canceled,
+// such as those fetching the values over the network, which are
+// handed the context given to LoadContext.
+type ContextLoader interface {
+	Loader
+	ReadContext(ctx context.Context, path string) (map[string]string, error)
+}
+
+// LoaderFunc adapts a function to a Loader.
+type LoaderFunc func(path string) (map[string]string, error)
+
+// Read implements Loader.
+func (f LoaderFunc) Read(path string) (map[string]string, error) {
+	return f(path)
+}
+
+// GodotenvLoader reads .env files with godotenv.
+// It's the Loader used by default. The errors
+// godotenv can't parse a file with are ErrParses,
+// locating the line with ParseLine, as godotenv
+// doesn't report it.
+type GodotenvLoader struct{}
+
+// Read implements Loader.
+func (GodotenvLoader) Read(path string) (map[string]string, error) {
+	m, err := godotenv.Read(path)
+	var pe *os.PathError
+	if err != nil && !errors.As(err, &pe) {
+		if perr, ok := locateParseError(path); ok {
+			return nil, perr
+		}
+		return nil, ErrParse{File: path, Err: err}
+	}
+	return m, err
+}
+
+// locateParseError returns the ErrParse of the first line of
+// the file ParseLine can't parse, reporting whether there's one.
+func locateParseError(path string) (ErrParse, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ErrParse{}, false
+	}
+	defer f.Close()
+
+	m := map[string]string{}
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		k, v, err := ParseLine(s.Text(), m)
+		if err != nil {
+			return newErrParse(path, n, s.Text(), err), true
+		}
+		if k != "" {
+			m[k] = v
+		}
+	}
+	return ErrParse{}, false
+}
+
+// newErrParse returns the ErrParse of the nth line of path.
+func newErrParse(path string, n int, line string, err error) ErrParse {
+	pe := ErrParse{File: path, Line: n, Content: line, Err: err}
+	var se *SyntaxError
+	if errors.As(err, &se) {
+		pe.Column, pe.Key = se.Column, se.Key
+	}
+	return pe
+}
+
+// NativeLoader reads .env files with envy's own parser, see ParseLine.
+// References are expanded against the variables defined earlier in
+// the same file.
+type NativeLoader struct{}
+
+// Read implements Loader.
+func (NativeLoader) Read(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m := map[string]string{}
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		k, v, err := ParseLine(line, m)
+		if err != nil {
+			return nil, newErrParse(path, n, line, err)
+		}
+		if k != "" {
+			m[k] = v
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SetLoader sets the Loader the Env reads files with.
+// A nil l restores the default, GodotenvLoader.
+func (e *Env) SetLoader(l Loader) {
+	if l == nil {
+		l = GodotenvLoader{}
+	}
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.loader = l
+}
+
+// SetLoader sets the Loader envy reads files with.
+func SetLoader(l Loader) {
+	env.SetLoader(l)
+}
+
+func (e *Env) getLoader() Loader {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	return e.loader
+}
+
+// read the file with the Loader of the Env, giving up when ctx is done.
+func (e *Env) read(ctx context.Context, file string) (map[string]string, error) {
+	l := e.getLoader()
+	if cl, ok := l.(ContextLoader); ok {
+		return cl.ReadContext(ctx, file)
+	}
+	if ctx.Done() == nil {
+		return l.Read(file)
+	}
+
+	type result struct {
+		m   map[string]string
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		m, err := l.Read(file)
+		ch <- result{m, err}
+	}()
+	select {
+	case r := <-ch:
+		return r.m, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,94 @@
+package envy
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_SetLoader(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{})
+	e.SetLoader(LoaderFunc(func(path string) (map[string]string, error) {
+		return map[string]string{"FROM": path}, nil
+	}))
+	r.NoError(e.Load("test_env/.env"))
+	r.Equal(map[string]string{"FROM": "test_env/.env"}, e.Map())
+
+	e.SetLoader(nil)
+	r.NoError(e.Load("test_env/.env"))
+	r.Equal("test_env", e.Get("DIR", ""))
+}
+
+func Test_NativeLoader(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithLoader(NativeLoader{}))
+	r.NoError(e.Load("test_env/.env"))
+	r.Equal("test_env", e.Get("DIR", ""))
+	r.Equal("none", e.Get("FLAVOUR", ""))
+
+	path := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(path, []byte("A=1\nB=${A}2\nC=\"unterminated\n"), 0600))
+	_, err := NativeLoader{}.Read(path)
+	r.Error(err)
+	r.True(strings.HasSuffix(err.Error(), "/.env:3:3: C: unterminated double quoted value"), err.Error())
+
+	r.NoError(ioutil.WriteFile(path, []byte("A=1\nB=${A}2\n"), 0600))
+	m, err := NativeLoader{}.Read(path)
+	r.NoError(err)
+	r.Equal(map[string]string{"A": "1", "B": "12"}, m)
+}
+
+type ctxLoader struct {
+	ctx context.Context
+}
+
+func (l *ctxLoader) Read(path string) (map[string]string, error) {
+	return l.ReadContext(context.Background(), path)
+}
+
+func (l *ctxLoader) ReadContext(ctx context.Context, path string) (map[string]string, error) {
+	l.ctx = ctx
+	return map[string]string{"FROM": path}, nil
+}
+
+func Test_Env_LoadContext(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	f := filepath.Join(dir, ".env")
+	r.NoError(ioutil.WriteFile(f, []byte("A=1\n"), 0644))
+
+	block := make(chan struct{})
+	defer close(block)
+	e := New(WithOSEnv(false), WithLoader(LoaderFunc(func(path string) (map[string]string, error) {
+		<-block
+		return map[string]string{"A": "1"}, nil
+	})))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := e.LoadContext(ctx, f)
+	r.ErrorIs(err, context.DeadlineExceeded)
+	_, ok := e.Lookup("A")
+	r.False(ok)
+
+	// a done context loads nothing
+	err = FromMap(nil).LoadContext(ctx, f)
+	r.ErrorIs(err, context.DeadlineExceeded)
+
+	l := &ctxLoader{}
+	e = New(WithOSEnv(false), WithLoader(l))
+	type key struct{}
+	ctx = context.WithValue(context.Background(), key{}, "v")
+	r.NoError(e.LoadContext(ctx, f))
+	r.Equal(f, e.Get("FROM", ""))
+	r.Equal("v", l.ctx.Value(key{}))
+}
@@ -0,0 +1,55 @@
+//go:build go1.21
+
+package envy
+
+import (
+	"log/slog"
+	"sort"
+)
+
+// logValuer logs the value of a single key of an Env.
+type logValuer struct {
+	env *Env
+	key string
+}
+
+// LogValue implements slog.LogValuer.
+func (l logValuer) LogValue() slog.Value {
+	v, _ := l.env.lookup(l.key)
+	l.env.moot.RLock()
+	v, _ = l.env.redactValue(l.key, v)
+	l.env.moot.RUnlock()
+	return slog.StringValue(v)
+}
+
+// LogValuer returns a slog.LogValuer for the value of key, which
+// is redacted like RedactedMap does. The value is read
+// when the record is logged, not when LogValuer is called.
+//
+//	slog.Info("starting", "db", e.LogValuer("DATABASE_URL"))
+func (e *Env) LogValuer(key string) slog.LogValuer {
+	return logValuer{env: e, key: key}
+}
+
+// LogValue implements slog.LogValuer, logging the Env as a group
+// of its keys, sorted, with the values of the secrets redacted.
+// Denied keys are left out, as they are from RedactedMap.
+func (e *Env) LogValue() slog.Value {
+	m := e.RedactedMap()
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, slog.String(k, m[k]))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValuer returns a slog.LogValuer for the value of
+// key, which is replaced by Redacted when it's a secret.
+func LogValuer(key string) slog.LogValuer {
+	return env.LogValuer(key)
+}
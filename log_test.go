@@ -0,0 +1,56 @@
+//go:build go1.21
+
+package envy
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_LogValuer(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"DATABASE_URL": "postgres://u:p@localhost/db",
+		"PORT":         "3000",
+	})
+	e.MarkSecret("DATABASE_URL")
+
+	bb := &bytes.Buffer{}
+	log := slog.New(slog.NewTextHandler(bb, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	log.Info("config", "db", e.LogValuer("DATABASE_URL"), "port", e.LogValuer("PORT"), "missing", e.LogValuer("MISSING"))
+	r.Equal("level=INFO msg=config db=***REDACTED*** port=3000 missing=\"\"\n", bb.String())
+}
+
+func Test_Env_LogValue(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"API_TOKEN": "abc",
+		"PORT":      "3000",
+		"LD_AUDIT":  "x",
+	})
+	r.NoError(e.Deny("LD_*"))
+
+	bb := &bytes.Buffer{}
+	log := slog.New(slog.NewJSONHandler(bb, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	log.Info("config", "env", e)
+	r.Equal(`{"level":"INFO","msg":"config","env":{"API_TOKEN":"***REDACTED***","PORT":"3000"}}`+"\n", bb.String())
+}
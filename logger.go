@@ -0,0 +1,40 @@
+package envy
+
+// Logger is what envy reports loads, reloads, and failures to. It's
+// implemented by *slog.Logger, and is easily adapted to others.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// nopLogger is the Logger an Env uses by default, discarding everything.
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...interface{}) {}
+func (nopLogger) Info(msg string, args ...interface{})  {}
+func (nopLogger) Warn(msg string, args ...interface{})  {}
+func (nopLogger) Error(msg string, args ...interface{}) {}
+
+// SetLogger sets the Logger of the Env, such as slog.Default().
+// A nil l restores the default, which discards everything.
+func (e *Env) SetLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.logger = l
+}
+
+// SetLogger sets the Logger of envy.
+func SetLogger(l Logger) {
+	env.SetLogger(l)
+}
+
+func (e *Env) getLogger() Logger {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	return e.logger
+}
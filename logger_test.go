@@ -0,0 +1,45 @@
+//go:build go1.21
+
+package envy
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_SetLogger(t *testing.T) {
+	r := require.New(t)
+
+	bb := &bytes.Buffer{}
+	l := slog.New(slog.NewTextHandler(bb, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	e := New(WithOSEnv(false))
+	e.SetLogger(l)
+	r.NoError(e.Load("test_env/.env"))
+	r.Error(e.Load("test_env/.env.nope"))
+
+	r.Equal(`level=INFO msg="envy: loaded" files=[test_env/.env]
+level=WARN msg="envy: could not load" files=[test_env/.env.nope] error="stat test_env/.env.nope: no such file or directory"
+`, bb.String())
+
+	bb.Reset()
+	e = New(WithLogger(l))
+	e.Reload()
+	r.Contains(bb.String(), `level=DEBUG msg="envy: reloaded" source=os`)
+
+	bb.Reset()
+	e.SetLogger(nil)
+	e.Reload()
+	r.Empty(bb.String())
+}
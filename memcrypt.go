@@ -0,0 +1,60 @@
+package envy
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"sync"
+)
+
+// memKey encrypts the secrets kept in memory, see WithEncryptedSecrets.
+// It's generated on first use, and never leaves the process.
+var memKey struct {
+	once sync.Once
+	gcm  cipher.AEAD
+}
+
+func memGCM() cipher.AEAD {
+	memKey.once.Do(func() {
+		key, err := GenerateKey()
+		if err != nil {
+			panic(err)
+		}
+		gcm, err := newGCM(key)
+		if err != nil {
+			panic(err)
+		}
+		wipe(key)
+		memKey.gcm = gcm
+	})
+	return memKey.gcm
+}
+
+// sealValue returns the buffer holding value, encrypted with
+// the process key when the Env encrypts its secrets.
+func (e *Env) sealValue(value string) []byte {
+	if !e.encrypt {
+		return []byte(value)
+	}
+	gcm := memGCM()
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(value)+gcm.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err)
+	}
+	return gcm.Seal(nonce, nonce, []byte(value), nil)
+}
+
+// openValue returns the value held by a buffer returned by sealValue.
+func (e *Env) openValue(b []byte) string {
+	if !e.encrypt {
+		return string(b)
+	}
+	gcm := memGCM()
+	n := gcm.NonceSize()
+	p, err := gcm.Open(nil, b[:n], b[n:], nil)
+	if err != nil {
+		panic("envy: corrupted in-memory secret")
+	}
+	v := string(p)
+	wipe(p)
+	return v
+}
@@ -0,0 +1,35 @@
+package envy
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_WithEncryptedSecrets(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithEncryptedSecrets(true))
+	e.Set("API_TOKEN", "plaintext-token")
+	e.Set("PORT", "3000")
+
+	r.NotContains(e.vars, "API_TOKEN")
+	r.False(bytes.Contains(e.sealed["API_TOKEN"], []byte("plaintext-token")))
+	r.NotContains(fmt.Sprintf("%#v", *e), "plaintext-token")
+
+	r.Equal("plaintext-token", e.Get("API_TOKEN", ""))
+	r.Equal("3000", e.Get("PORT", ""))
+	r.Equal("***REDACTED***", e.RedactedMap()["API_TOKEN"])
+
+	c := e.Clone()
+	r.Equal("plaintext-token", c.Get("API_TOKEN", ""))
+	r.NotContains(c.vars, "API_TOKEN")
+
+	e.Temp(func() {
+		e.Set("API_TOKEN", "tmp")
+		r.Equal("tmp", e.Get("API_TOKEN", ""))
+	})
+	r.Equal("plaintext-token", e.Get("API_TOKEN", ""))
+}
@@ -0,0 +1,56 @@
+package envy
+
+import "time"
+
+// Metrics receives the counters and timings of an Env, so operators
+// can see, for example, which keys are missing in production, and
+// silently fall back to their defaults. Implementations must be
+// safe for concurrent use, and fast, as they're called synchronously.
+type Metrics interface {
+	// Hit is called when key is read, and found.
+	Hit(key string)
+	// Miss is called when key is read, and not found.
+	Miss(key string)
+	// Loaded is called when Load completes, with how long it took.
+	Loaded(d time.Duration, err error)
+	// Refreshed is called when the values from source are
+	// refreshed, such as when Reload re-reads the "os".
+	Refreshed(source string, d time.Duration, err error)
+}
+
+// NopMetrics is the Metrics an Env uses by default, ignoring everything.
+type NopMetrics struct{}
+
+// Hit implements Metrics.
+func (NopMetrics) Hit(key string) {}
+
+// Miss implements Metrics.
+func (NopMetrics) Miss(key string) {}
+
+// Loaded implements Metrics.
+func (NopMetrics) Loaded(d time.Duration, err error) {}
+
+// Refreshed implements Metrics.
+func (NopMetrics) Refreshed(source string, d time.Duration, err error) {}
+
+// SetMetrics sets the Metrics of the Env. A nil m
+// restores the default, NopMetrics.
+func (e *Env) SetMetrics(m Metrics) {
+	if m == nil {
+		m = NopMetrics{}
+	}
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.metrics = m
+}
+
+// SetMetrics sets the Metrics of envy.
+func SetMetrics(m Metrics) {
+	env.SetMetrics(m)
+}
+
+func (e *Env) getMetrics() Metrics {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	return e.metrics
+}
@@ -0,0 +1,71 @@
+package envy_test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gobuffalo/envy"
+)
+
+// promMetrics counts the reads of every key, in a form that's easy
+// to expose to Prometheus, here written in its text format. A real
+// implementation would use the counters and histograms of the
+// Prometheus client instead.
+type promMetrics struct {
+	moot  sync.Mutex
+	reads map[[2]string]int
+	loads int
+}
+
+func (m *promMetrics) Hit(key string)  { m.inc(key, "hit") }
+func (m *promMetrics) Miss(key string) { m.inc(key, "miss") }
+
+func (m *promMetrics) inc(key, result string) {
+	m.moot.Lock()
+	defer m.moot.Unlock()
+	m.reads[[2]string{key, result}]++
+}
+
+func (m *promMetrics) Loaded(d time.Duration, err error) {
+	m.moot.Lock()
+	defer m.moot.Unlock()
+	m.loads++
+}
+
+func (m *promMetrics) Refreshed(source string, d time.Duration, err error) {}
+
+func (m *promMetrics) Write(w io.Writer) {
+	m.moot.Lock()
+	defer m.moot.Unlock()
+	keys := make([][2]string, 0, len(m.reads))
+	for k := range m.reads {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i][0]+keys[i][1] < keys[j][0]+keys[j][1]
+	})
+	for _, k := range keys {
+		fmt.Fprintf(w, "envy_reads_total{key=%q,result=%q} %d\n", k[0], k[1], m.reads[k])
+	}
+	fmt.Fprintf(w, "envy_loads_total %d\n", m.loads)
+}
+
+func ExampleMetrics() {
+	m := &promMetrics{reads: map[[2]string]int{}}
+	e := envy.New(envy.WithOSEnv(false), envy.WithMetrics(m))
+	e.Set("PORT", "3000")
+
+	e.Get("PORT", "8080")
+	e.Get("HOST", "localhost")
+	e.Load("test_env/.env")
+
+	m.Write(os.Stdout)
+	// Output:
+	// envy_reads_total{key="HOST",result="miss"} 1
+	// envy_reads_total{key="PORT",result="hit"} 1
+	// envy_loads_total 1
+}
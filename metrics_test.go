@@ -0,0 +1,62 @@
+package envy
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testMetrics struct {
+	moot   sync.Mutex
+	hits   map[string]int
+	misses map[string]int
+	loads  []error
+}
+
+func (m *testMetrics) Hit(key string) {
+	m.moot.Lock()
+	defer m.moot.Unlock()
+	m.hits[key]++
+}
+
+func (m *testMetrics) Miss(key string) {
+	m.moot.Lock()
+	defer m.moot.Unlock()
+	m.misses[key]++
+}
+
+func (m *testMetrics) Loaded(d time.Duration, err error) {
+	m.moot.Lock()
+	defer m.moot.Unlock()
+	m.loads = append(m.loads, err)
+}
+
+func (m *testMetrics) Refreshed(source string, d time.Duration, err error) {}
+
+func Test_Env_Metrics(t *testing.T) {
+	r := require.New(t)
+
+	m := &testMetrics{hits: map[string]int{}, misses: map[string]int{}}
+	e := New(WithOSEnv(false), WithMetrics(m))
+	e.Set("PORT", "3000")
+
+	e.Get("PORT", "")
+	e.Get("PORT", "")
+	e.Get("HOST", "localhost")
+	e.MustGet("HOST")
+
+	r.NoError(e.Load("test_env/.env"))
+	r.Error(e.Load("test_env/.env.nope"))
+
+	r.Equal(map[string]int{"PORT": 2}, m.hits)
+	r.Equal(map[string]int{"HOST": 2}, m.misses)
+	r.Len(m.loads, 2)
+	r.NoError(m.loads[0])
+	r.Error(m.loads[1])
+
+	e.SetMetrics(nil)
+	e.Get("PORT", "")
+	r.Equal(map[string]int{"PORT": 2}, m.hits)
+}
@@ -0,0 +1,27 @@
+package envy
+
+import "fmt"
+
+// Must returns v, or panics when err isn't nil. It's meant for the
+// configuration read at startup, where crashing is the right thing
+// to do when it's missing or invalid:
+//
+//	port := envy.Must(strconv.Atoi(envy.MustGetOrPanic("PORT")))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(fmt.Errorf("envy: %w", err))
+	}
+	return v
+}
+
+// MustGetOrPanic returns the value of key, or panics,
+// naming the key, when it isn't set.
+func (e *Env) MustGetOrPanic(key string) string {
+	return Must(e.MustGet(key))
+}
+
+// MustGetOrPanic returns the value of key, or panics,
+// naming the key, when it isn't set in envy.
+func MustGetOrPanic(key string) string {
+	return env.MustGetOrPanic(key)
+}
@@ -0,0 +1,33 @@
+package envy
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Must(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal(3000, Must(strconv.Atoi("3000")))
+	r.PanicsWithError(`envy: strconv.Atoi: parsing "x": invalid syntax`, func() {
+		Must(strconv.Atoi("x"))
+	})
+}
+
+func Test_Env_MustGetOrPanic(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"PORT": "3000"})
+	r.Equal("3000", e.MustGetOrPanic("PORT"))
+
+	defer func() {
+		err, ok := recover().(error)
+		r.True(ok)
+		r.EqualError(err, "envy: could not find ENV var with NOPE")
+		r.True(errors.Is(err, ErrKeyNotFound{Key: "NOPE"}))
+	}()
+	e.MustGetOrPanic("NOPE")
+}
@@ -0,0 +1,100 @@
+package envy
+
+import (
+	"sort"
+	"strings"
+)
+
+// WithDelimiter sets the delimiter of the paths given to GetNested
+// and SubMap, "." by default.
+func WithDelimiter(d string) Option {
+	return func(e *Env) {
+		e.delimiter = d
+	}
+}
+
+// nestedKey maps a path, such as database.primary.host,
+// onto its key, DATABASE_PRIMARY_HOST.
+func (e *Env) nestedKey(path string) string {
+	d := e.delimiter
+	if d == "" {
+		d = "."
+	}
+	return strings.ToUpper(strings.ReplaceAll(path, d, "_"))
+}
+
+// GetNested looks up the key a path of hierarchical config, such as
+// "database.primary.host", maps to: DATABASE_PRIMARY_HOST. The
+// delimiter of the path can be set with WithDelimiter.
+func (e *Env) GetNested(path string) (string, bool) {
+	return e.Lookup(e.nestedKey(path))
+}
+
+// GetNested looks up the key a path, such as
+// "database.primary.host", maps to in envy.
+func GetNested(path string) (string, bool) {
+	return env.GetNested(path)
+}
+
+// SubMap returns the keys under path, such as "database", as a nested
+// map, for consumers of hierarchical config: DATABASE_PRIMARY_HOST is
+// found at m["primary"].(map[string]interface{})["host"]. The names in
+// the map are lower-cased, and split on underscores. When a key is
+// both set, and the parent of other keys, such as DATABASE_PRIMARY and
+// DATABASE_PRIMARY_HOST, the parent is left out. Of the keys only
+// differing by their case, the first one in byte order, such as
+// DATABASE_HOST rather than DATABASE_Host, is kept. An empty path
+// returns every key.
+func (e *Env) SubMap(path string) map[string]interface{} {
+	prefix := ""
+	if path != "" {
+		prefix = e.nestedKey(path) + "_"
+	}
+
+	vars := e.Map()
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		if strings.HasPrefix(k, prefix) && len(k) > len(prefix) {
+			keys = append(keys, k)
+		}
+	}
+	// the longer keys first, so the parents are left out, then
+	// sorted, so of the keys only differing by their case, such as
+	// DATABASE_HOST and DATABASE_Host, the same one always wins
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	m := map[string]interface{}{}
+	for _, k := range keys {
+		parts := strings.Split(strings.ToLower(k[len(prefix):]), "_")
+		insertNested(m, parts, vars[k])
+	}
+	return m
+}
+
+// SubMap returns the keys of envy under path as a nested map.
+func SubMap(path string) map[string]interface{} {
+	return env.SubMap(path)
+}
+
+func insertNested(m map[string]interface{}, parts []string, v string) {
+	for _, p := range parts[:len(parts)-1] {
+		sub, ok := m[p].(map[string]interface{})
+		if !ok {
+			if _, set := m[p]; set {
+				return
+			}
+			sub = map[string]interface{}{}
+			m[p] = sub
+		}
+		m = sub
+	}
+	last := parts[len(parts)-1]
+	if _, ok := m[last]; !ok {
+		m[last] = v
+	}
+}
@@ -0,0 +1,64 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_GetNested(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"DATABASE_PRIMARY_HOST": "db1"})
+	v, ok := e.GetNested("database.primary.host")
+	r.True(ok)
+	r.Equal("db1", v)
+
+	_, ok = e.GetNested("database.replica.host")
+	r.False(ok)
+
+	e = New(WithOSEnv(false), WithDelimiter("/"))
+	e.Set("DATABASE_PRIMARY_HOST", "db2")
+	v, ok = e.GetNested("database/primary/host")
+	r.True(ok)
+	r.Equal("db2", v)
+}
+
+func Test_Env_SubMap(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"DATABASE_PRIMARY_HOST": "db1",
+		"DATABASE_PRIMARY_PORT": "5432",
+		"DATABASE_PRIMARY":      "ignored",
+		"DATABASE_POOL":         "10",
+		"DATABASE":              "ignored too",
+		"PORT":                  "3000",
+	})
+
+	r.Equal(map[string]interface{}{
+		"primary": map[string]interface{}{
+			"host": "db1",
+			"port": "5432",
+		},
+		"pool": "10",
+	}, e.SubMap("database"))
+
+	r.Equal(map[string]interface{}{
+		"host": "db1",
+		"port": "5432",
+	}, e.SubMap("database.primary"))
+
+	r.Equal("3000", e.SubMap("")["port"])
+	r.Empty(e.SubMap("nope"))
+
+	// the keys only differing by their case always resolve the same
+	e = FromMap(map[string]string{
+		"DATABASE_HOST": "db1",
+		"DATABASE_Host": "db2",
+		"DATABASE_host": "db3",
+	})
+	for i := 0; i < 20; i++ {
+		r.Equal("db1", e.SubMap("database")["host"])
+	}
+}
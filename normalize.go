@@ -0,0 +1,22 @@
+package envy
+
+import "strings"
+
+// NormalizeKey maps key onto an ENV style name: dots, dashes, and
+// spaces are replaced by underscores, and letters are upper-cased,
+// so "database.url", from a YAML file, and "log-level", from a flag,
+// become DATABASE_URL and LOG_LEVEL.
+func NormalizeKey(key string) string {
+	return strings.ToUpper(keyReplacer.Replace(key))
+}
+
+var keyReplacer = strings.NewReplacer(".", "_", "-", "_", " ", "_")
+
+// WithNormalizer sets a func, such as NormalizeKey, the keys that
+// aren't found are normalized with before they're looked up again,
+// so Get("database.url", "") finds DATABASE_URL.
+func WithNormalizer(fn func(key string) string) Option {
+	return func(e *Env) {
+		e.normalize = fn
+	}
+}
@@ -0,0 +1,33 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NormalizeKey(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal("DATABASE_URL", NormalizeKey("database.url"))
+	r.Equal("LOG_LEVEL", NormalizeKey("log-level"))
+	r.Equal("A_B_C", NormalizeKey("a.b c"))
+	r.Equal("PORT", NormalizeKey("PORT"))
+}
+
+func Test_WithNormalizer(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithNormalizer(NormalizeKey))
+	e.Set("DATABASE_URL", "postgres://")
+	e.Set("http_proxy", "http://proxy")
+
+	r.Equal("postgres://", e.Get("database.url", ""))
+	r.Equal("postgres://", e.Get("database-url", ""))
+	r.Equal("http://proxy", e.Get("http_proxy", ""))
+	r.Equal("x", e.Get("nope.nope", "x"))
+	r.Equal("postgres://", e.Clone().Get("database.url", ""))
+
+	_, ok := FromMap(map[string]string{"DATABASE_URL": "x"}).Lookup("database.url")
+	r.False(ok)
+}
@@ -0,0 +1,134 @@
+package envy
+
+// Option configures an Env created by New.
+type Option func(e *Env)
+
+// WithOSEnv controls whether the Env is bound to the OS environment.
+// It is by default. When it isn't, the Env starts empty and only
+// ever contains the values explicitly loaded or set on it, so it
+// isn't affected by whatever the host happens to export.
+func WithOSEnv(b bool) Option {
+	return func(e *Env) {
+		e.osenv = b
+	}
+}
+
+// WithAutoLoad controls whether the Env sees the values of ./.env,
+// which the package loads into the OS environment when it's
+// initialized, like the default Env does. Without it, the Env holds
+// the OS environment without them, for tools, such as the envy
+// command, only loading the files they're told to, and, like an Env
+// returned by Clone, it isn't bound to the OS.
+func WithAutoLoad(b bool) Option {
+	return func(e *Env) {
+		e.noAutoLoad = !b
+	}
+}
+
+// WithWipedSecrets controls whether the values of the secrets, see
+// MarkSecret, are kept in byte buffers that are zeroed when they're
+// replaced, or removed with Unset, Clear, or Wipe, rather than in
+// strings that linger in memory until they're garbage collected and
+// overwritten. Get still returns a copy of the value as a string,
+// and the OS environment, for an Env bound to it, is unaffected.
+func WithWipedSecrets(b bool) Option {
+	return func(e *Env) {
+		e.wipe = b
+	}
+}
+
+// WithEncryptedSecrets controls whether the values of the secrets,
+// see MarkSecret, are kept encrypted in memory, with a random key
+// generated for the process, and only decrypted when they're read,
+// so neither a dump of the Env's internals nor a core dump exposes
+// them in plain text. The buffers holding them are zeroed like with
+// WithWipedSecrets.
+func WithEncryptedSecrets(b bool) Option {
+	return func(e *Env) {
+		e.encrypt = b
+	}
+}
+
+// WithMetrics sets the Metrics of the Env, see SetMetrics.
+func WithMetrics(m Metrics) Option {
+	return func(e *Env) {
+		if m != nil {
+			e.metrics = m
+		}
+	}
+}
+
+// WithLogger sets the Logger of the Env, see SetLogger. Unlike
+// SetLogger, it also gets what's reported while the Env is created.
+func WithLogger(l Logger) Option {
+	return func(e *Env) {
+		if l != nil {
+			e.logger = l
+		}
+	}
+}
+
+// WithSettings seeds the Env with the settings of s, such as a
+// *viper.Viper, see LoadSettings. For an Env bound to the OS,
+// the OS environment, and the files loaded later on, take precedence
+// over them, and they're kept when it's reloaded. They're set once
+// all the options are applied, so the secrets are sealed, see
+// WithWipedSecrets, whatever the order of the options.
+func WithSettings(s Settings) Option {
+	return func(e *Env) {
+		e.settings = append(e.settings, s)
+	}
+}
+
+// seedSettings adds the settings of WithSettings to the settings
+// of the Env, and sets them, see applySettings.
+func (e *Env) seedSettings() {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	for _, s := range e.settings {
+		for k, v := range flattenSettings(s.AllSettings()) {
+			e.settingVars[k] = v
+		}
+	}
+	e.settings = nil
+	e.applySettings()
+}
+
+// applySettings sets the settings of the Env, other than those the OS
+// environment, or a loaded file, set, with "settings" as their source.
+// As Reload rebuilds the variables of an Env bound to the OS from the
+// OS environment, they're applied again after it. It must be called
+// with e.moot held.
+func (e *Env) applySettings() {
+	for k, v := range e.settingVars {
+		k = e.canon(k)
+		if _, ok := e.get(k); ok && e.sources[k] != "settings" {
+			continue
+		}
+		e.store(k, v)
+		e.sources[k] = "settings"
+	}
+}
+
+// WithGoTool controls whether the Env may run the go binary, which
+// it does, by default, to find the GOPATH when it isn't set, and for
+// GoEnv, GoVersion, and the functions built on them. Without it, for
+// hosts where Go isn't installed, such as scratch containers, GOPATH
+// is left unset, GoEnv reports the go tool is disabled, GoMod returns
+// the empty string, GoVersion the version the program was built with,
+// and GoRoot, GoToolchain, GoCache, and GoModCache fall back on the
+// variables of the Env.
+func WithGoTool(b bool) Option {
+	return func(e *Env) {
+		e.gotool = b
+	}
+}
+
+// WithLoader sets the Loader the Env reads files with, see SetLoader.
+func WithLoader(l Loader) Option {
+	return func(e *Env) {
+		if l != nil {
+			e.loader = l
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package envy
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithOSEnv(t *testing.T) {
+	r := require.New(t)
+
+	r.NotZero(os.Getenv("GOPATH"))
+
+	e := New(WithOSEnv(false))
+	r.Empty(e.Map())
+	r.Equal("", e.Get("GOPATH", ""))
+
+	r.NoError(e.Load("test_env/.env"))
+	r.Equal("test_env", e.Get("DIR", ""))
+	r.NotEqual("test_env", os.Getenv("DIR"))
+
+	r.NoError(e.MustSet("ENVY_HERMETIC", "x"))
+	r.Zero(os.Getenv("ENVY_HERMETIC"))
+
+	e = New(WithOSEnv(true))
+	r.Equal(os.Getenv("GOPATH"), e.Get("GOPATH", ""))
+}
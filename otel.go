@@ -0,0 +1,53 @@
+package envy
+
+import "strings"
+
+// OTelKeys maps the keys OTelAttributes always converts to the
+// OpenTelemetry semantic convention attributes they correspond to.
+var OTelKeys = map[string]string{
+	"SERVICE_NAME":      "service.name",
+	"SERVICE_NAMESPACE": "service.namespace",
+	"SERVICE_VERSION":   "service.version",
+	"DEPLOY_ENV":        "deployment.environment",
+}
+
+// OTelAttributes returns OpenTelemetry resource attributes for the
+// keys in OTelKeys, and for the keys starting with prefix, which is
+// stripped, with the rest of the key lowercased and its underscores
+// replaced by dots; with the prefix "OTEL_ATTR_", OTEL_ATTR_HOST_NAME
+// becomes host.name. An empty prefix only converts OTelKeys. Secrets
+// and denied keys are never converted.
+//
+// They're returned as a map, so tracing can be set up without envy
+// depending on OpenTelemetry:
+//
+//	var attrs []attribute.KeyValue
+//	for k, v := range envy.Default().OTelAttributes("OTEL_ATTR_") {
+//		attrs = append(attrs, attribute.String(k, v))
+//	}
+//	res := resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+func (e *Env) OTelAttributes(prefix string) map[string]string {
+	m := e.Map()
+	attrs := map[string]string{}
+	for k, v := range m {
+		if v == "" || e.IsSecret(k) {
+			continue
+		}
+		if a, ok := OTelKeys[k]; ok {
+			attrs[a] = v
+			continue
+		}
+		if prefix == "" || !strings.HasPrefix(k, prefix) || len(k) == len(prefix) {
+			continue
+		}
+		a := strings.ToLower(strings.TrimPrefix(k, prefix))
+		attrs[strings.ReplaceAll(a, "_", ".")] = v
+	}
+	return attrs
+}
+
+// OTelAttributes returns OpenTelemetry resource
+// attributes for the keys in envy.
+func OTelAttributes(prefix string) map[string]string {
+	return env.OTelAttributes(prefix)
+}
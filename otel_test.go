@@ -0,0 +1,32 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_OTelAttributes(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"SERVICE_NAME":        "coke",
+		"DEPLOY_ENV":          "production",
+		"SERVICE_VERSION":     "",
+		"OTEL_ATTR_HOST_NAME": "web-1",
+		"OTEL_ATTR_API_TOKEN": "abc",
+		"OTEL_ATTR_":          "nope",
+		"PORT":                "3000",
+	})
+
+	r.Equal(map[string]string{
+		"service.name":           "coke",
+		"deployment.environment": "production",
+		"host.name":              "web-1",
+	}, e.OTelAttributes("OTEL_ATTR_"))
+
+	r.Equal(map[string]string{
+		"service.name":           "coke",
+		"deployment.environment": "production",
+	}, e.OTelAttributes(""))
+}
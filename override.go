@@ -0,0 +1,71 @@
+package envy
+
+import "sync"
+
+// Override sets the given values, like Set, and returns a func that
+// restores the overridden keys to their previous values, and sources,
+// removing those that weren't set. Unlike Temp, it composes with
+// defer, and only restores the keys it set, so the changes made to
+// other keys in the meantime, from other goroutines too, are kept:
+//
+//	restore := e.Override(map[string]string{"GO_ENV": "test"})
+//	defer restore()
+//
+// Calling the returned func more than once has no further effect.
+func (e *Env) Override(values map[string]string) func() {
+	e.moot.Lock()
+	prev := map[string]string{}
+	sources := map[string]string{}
+	unset := map[string]bool{}
+	var calls []func()
+	for k, v := range values {
+		k = e.canon(k)
+		if ov, ok := e.get(k); ok {
+			prev[k] = ov
+			if src, ok := e.sources[k]; ok {
+				sources[k] = src
+			}
+		} else {
+			unset[k] = true
+		}
+		calls = append(calls, e.set(k, v)...)
+	}
+	e.moot.Unlock()
+	runAll(calls)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			e.moot.Lock()
+			cur := map[string]string{}
+			for k := range values {
+				k = e.canon(k)
+				if v, ok := e.get(k); ok {
+					cur[k] = v
+				}
+			}
+			for k := range unset {
+				e.unseal(k)
+				delete(e.vars, k)
+				delete(e.sources, k)
+			}
+			for k, v := range prev {
+				e.store(k, v)
+				if src, ok := sources[k]; ok {
+					e.sources[k] = src
+				} else {
+					delete(e.sources, k)
+				}
+			}
+			calls := e.changed(cur, prev)
+			e.moot.Unlock()
+			runAll(calls)
+		})
+	}
+}
+
+// Override sets the given values in envy, returning a
+// func that restores the overridden keys.
+func Override(values map[string]string) func() {
+	return env.Override(values)
+}
@@ -0,0 +1,68 @@
+package envy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Override(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"GO_ENV": "development", "PORT": "3000"})
+
+	var changes []Change
+	e.OnChange(func(c Change) {
+		changes = append(changes, c)
+	})
+
+	restore := e.Override(map[string]string{"GO_ENV": "test", "DEBUG": "true"})
+	r.Equal("test", e.Get("GO_ENV", ""))
+	r.Equal("true", e.Get("DEBUG", ""))
+
+	// changes to the other keys are kept
+	e.Set("PORT", "4000")
+
+	restore()
+	r.Equal(map[string]string{"GO_ENV": "development", "PORT": "4000"}, e.Map())
+	r.Len(changes, 5)
+
+	// only once
+	e.Set("GO_ENV", "production")
+	restore()
+	r.Equal("production", e.Get("GO_ENV", ""))
+}
+
+func Test_Env_Override_Sources(t *testing.T) {
+	r := require.New(t)
+
+	path := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(path, []byte("GO_ENV=development\n"), 0o600))
+
+	e := FromMap(map[string]string{"PORT": "3000"})
+	r.NoError(e.Load(path))
+
+	restore := e.Override(map[string]string{"GO_ENV": "test", "PORT": "4000"})
+	r.Equal("set", e.sources["GO_ENV"])
+	restore()
+
+	r.Equal(path, e.sources["GO_ENV"])
+	_, ok := e.sources["PORT"]
+	r.False(ok)
+}
+
+func Test_Override(t *testing.T) {
+	r := require.New(t)
+
+	_, ok := Lookup("ENVY_OVERRIDE")
+	r.False(ok)
+
+	restore := Override(map[string]string{"ENVY_OVERRIDE": "x"})
+	r.Equal("x", Get("ENVY_OVERRIDE", ""))
+	restore()
+
+	_, ok = Lookup("ENVY_OVERRIDE")
+	r.False(ok)
+}
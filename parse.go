@@ -0,0 +1,226 @@
+package envy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseLine parses a single line of a .env file into its key and
+// value. Blank lines and comments return an empty key and no error.
+//
+// Both KEY=value and KEY: value are accepted, optionally prefixed
+// with "export". Single quoted values are taken literally. Double
+// quoted values understand the \n, \r, \t, \", \$ and \\ escapes. Double
+// quoted and unquoted values have their ${VAR} and $VAR references
+// expanded against vars, see ExpandValue. Unquoted values end at
+// the first " #", which starts a comment.
+//
+// The errors returned are *SyntaxErrors.
+//
+// ParseLine doesn't modify vars, and has no other side effects.
+func ParseLine(line string, vars map[string]string) (key string, value string, err error) {
+	// off is the index, in the original line, of the start of line
+	off := leadingSpace(line)
+	line = strings.TrimSpace(line)
+	if len(line) == 0 || line[0] == '#' {
+		return "", "", nil
+	}
+
+	if strings.HasPrefix(line, "export ") || strings.HasPrefix(line, "export\t") {
+		rest := strings.TrimSpace(line[len("export"):])
+		off += len(line) - len(rest)
+		line = rest
+	}
+
+	i := strings.IndexAny(line, "=:")
+	if i == -1 {
+		return "", "", &SyntaxError{Msg: "can't separate key from value", Column: off + 1}
+	}
+
+	key = strings.TrimSpace(line[:i])
+	if len(key) == 0 {
+		return "", "", &SyntaxError{Msg: "missing key", Column: off + i + 1}
+	}
+	if strings.ContainsAny(key, " \t\"'") {
+		return "", "", &SyntaxError{Msg: fmt.Sprintf("invalid key %q", key), Column: off + 1}
+	}
+
+	raw := line[i+1:]
+	value, err = parseValue(strings.TrimSpace(raw), vars)
+	if err != nil {
+		se := err.(*SyntaxError)
+		se.Key = key
+		se.Column += off + i + 1 + leadingSpace(raw)
+		return "", "", se
+	}
+	return key, value, nil
+}
+
+// SyntaxError describes why a line of a .env file can't be parsed.
+type SyntaxError struct {
+	Msg string
+	// Column, starting at 1, of the byte of the line where the
+	// error was found.
+	Column int
+	// Key whose value can't be parsed, if the key was.
+	Key string
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Msg
+}
+
+func leadingSpace(s string) int {
+	return len(s) - len(strings.TrimLeftFunc(s, unicode.IsSpace))
+}
+
+// parseValue parses the raw value of a line; the Columns of the
+// errors it returns are relative to the start of raw.
+func parseValue(raw string, vars map[string]string) (string, error) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+
+	switch raw[0] {
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end == -1 {
+			return "", &SyntaxError{Msg: "unterminated single quoted value", Column: 1}
+		}
+		if err := checkTrailing(raw, end+2); err != nil {
+			return "", err
+		}
+		return raw[1 : end+1], nil
+	case '"':
+		bb := &strings.Builder{}
+		for i := 1; i < len(raw); i++ {
+			c := raw[i]
+			switch c {
+			case '"':
+				if err := checkTrailing(raw, i+1); err != nil {
+					return "", err
+				}
+				return bb.String(), nil
+			case '\\':
+				if i+1 == len(raw) {
+					break
+				}
+				i++
+				switch raw[i] {
+				case 'n':
+					bb.WriteByte('\n')
+				case 'r':
+					bb.WriteByte('\r')
+				case 't':
+					bb.WriteByte('\t')
+				default:
+					bb.WriteByte(raw[i])
+				}
+			case '$':
+				v, n := expandRef(raw[i:], vars)
+				if n == 0 {
+					bb.WriteByte(c)
+					break
+				}
+				bb.WriteString(v)
+				i += n - 1
+			default:
+				bb.WriteByte(c)
+			}
+		}
+		return "", &SyntaxError{Msg: "unterminated double quoted value", Column: 1}
+	case '#':
+		return "", nil
+	}
+
+	if i := strings.Index(raw, " #"); i != -1 {
+		raw = raw[:i]
+	}
+	if i := strings.Index(raw, "\t#"); i != -1 {
+		raw = raw[:i]
+	}
+	return ExpandValue(strings.TrimSpace(raw), vars), nil
+}
+
+// checkTrailing makes sure nothing but a comment follows
+// the quoted value of raw, which ends before raw[at].
+func checkTrailing(raw string, at int) error {
+	s := raw[at:]
+	col := at + leadingSpace(s) + 1
+	s = strings.TrimSpace(s)
+	if len(s) == 0 || s[0] == '#' {
+		return nil
+	}
+	return &SyntaxError{Msg: fmt.Sprintf("unexpected %q after quoted value", s), Column: col}
+}
+
+// ExpandValue replaces the ${VAR} and $VAR references in value with
+// the corresponding values in vars. Unknown variables are replaced
+// with an empty string. A reference escaped as \$VAR is kept
+// literally, without the backslash.
+//
+// ExpandValue doesn't modify vars, and has no other side effects.
+func ExpandValue(value string, vars map[string]string) string {
+	if !strings.Contains(value, "$") {
+		return value
+	}
+
+	bb := &strings.Builder{}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\\' && i+1 < len(value) && value[i+1] == '$' {
+			bb.WriteByte('$')
+			i++
+			continue
+		}
+		if c == '$' {
+			if v, n := expandRef(value[i:], vars); n > 0 {
+				bb.WriteString(v)
+				i += n - 1
+				continue
+			}
+		}
+		bb.WriteByte(c)
+	}
+	return bb.String()
+}
+
+// expandRef expands the reference at the start of s, which starts
+// with a '$', returning the value and the length of the reference.
+// The length is 0 when s doesn't start with a valid reference.
+func expandRef(s string, vars map[string]string) (string, int) {
+	if len(s) < 2 {
+		return "", 0
+	}
+	if s[1] == '{' {
+		end := strings.IndexByte(s, '}')
+		if end == -1 || !isVarName(s[2:end]) {
+			return "", 0
+		}
+		return vars[s[2:end]], end + 1
+	}
+	n := varNameLen(s[1:])
+	if n == 0 {
+		return "", 0
+	}
+	return vars[s[1:1+n]], n + 1
+}
+
+func isVarName(s string) bool {
+	return len(s) > 0 && varNameLen(s) == len(s)
+}
+
+// varNameLen returns the length of the variable name at the start of s.
+func varNameLen(s string) int {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9' && i > 0:
+		default:
+			return i
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,127 @@
+package envy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLine(t *testing.T) {
+	vars := map[string]string{"HOME": "/home/envy", "N": "1"}
+
+	table := []struct {
+		line  string
+		key   string
+		value string
+	}{
+		{"", "", ""},
+		{"   # a comment", "", ""},
+		{"FOO=bar", "FOO", "bar"},
+		{"FOO: bar", "FOO", "bar"},
+		{"export FOO=bar", "FOO", "bar"},
+		{"  FOO = bar  ", "FOO", "bar"},
+		{"FOO=", "FOO", ""},
+		{"FOO=bar # comment", "FOO", "bar"},
+		{"FOO=bar#baz", "FOO", "bar#baz"},
+		{"FOO=a=b=c", "FOO", "a=b=c"},
+		{"FOO='$HOME # \\n'", "FOO", "$HOME # \\n"},
+		{`FOO="a\nb\t\"c\" \$HOME \\"`, "FOO", "a\nb\t\"c\" $HOME \\"},
+		{`FOO="$HOME/${N}" # comment`, "FOO", "/home/envy/1"},
+		{"FOO=$HOME/${N}/$MISSING", "FOO", "/home/envy/1/"},
+		{"URL=postgres://u:p@h:5432/db", "URL", "postgres://u:p@h:5432/db"},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.line, func(st *testing.T) {
+			r := require.New(st)
+			k, v, err := ParseLine(tt.line, vars)
+			r.NoError(err)
+			r.Equal(tt.key, k)
+			r.Equal(tt.value, v)
+		})
+	}
+}
+
+func Test_ParseLine_Errors(t *testing.T) {
+	table := []string{
+		"FOO",
+		"=bar",
+		"FOO BAR=baz",
+		`FOO="bar`,
+		"FOO='bar",
+		`FOO="bar" baz`,
+	}
+
+	for _, line := range table {
+		t.Run(line, func(st *testing.T) {
+			_, _, err := ParseLine(line, nil)
+			require.Error(st, err)
+		})
+	}
+}
+
+func Test_ParseLine_SyntaxError(t *testing.T) {
+	table := []struct {
+		line   string
+		column int
+		key    string
+	}{
+		{"FOO", 1, ""},
+		{"  FOO", 3, ""},
+		{"export =bar", 8, ""},
+		{"FOO BAR=baz", 1, ""},
+		{`FOO="bar`, 5, "FOO"},
+		{`export  FOO = 'bar`, 15, "FOO"},
+		{`FOO="bar"  baz`, 12, "FOO"},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.line, func(st *testing.T) {
+			r := require.New(st)
+			_, _, err := ParseLine(tt.line, nil)
+			var se *SyntaxError
+			r.True(errors.As(err, &se))
+			r.Equal(tt.column, se.Column)
+			r.Equal(tt.key, se.Key)
+		})
+	}
+}
+
+func Test_ExpandValue(t *testing.T) {
+	r := require.New(t)
+	vars := map[string]string{"A": "a", "B_1": "b"}
+
+	r.Equal("a-b-", ExpandValue("$A-${B_1}-$C", vars))
+	r.Equal("$A ${A", ExpandValue(`\$A ${A`, vars))
+	r.Equal("$ $1 ${} ${-}", ExpandValue("$ $1 ${} ${-}", vars))
+	r.Equal("no refs", ExpandValue("no refs", nil))
+}
+
+func FuzzParseLine(f *testing.F) {
+	f.Add("FOO=bar")
+	f.Add(`export FOO="a\nb $BAR" # comment`)
+	f.Add("FOO: 'single'")
+	f.Fuzz(func(t *testing.T, line string) {
+		k, _, err := ParseLine(line, map[string]string{"BAR": "bar"})
+		if err != nil {
+			return
+		}
+		if strings.ContainsAny(k, "=: \t\"'") {
+			t.Fatalf("invalid key %q parsed from %q", k, line)
+		}
+	})
+}
+
+func FuzzExpandValue(f *testing.F) {
+	f.Add("$FOO")
+	f.Add("${FOO}-$BAR")
+	f.Add(`\$FOO ${`)
+	f.Fuzz(func(t *testing.T, value string) {
+		got := ExpandValue(value, map[string]string{"FOO": "foo"})
+		if !strings.Contains(value, "$") && got != value {
+			t.Fatalf("%q expanded to %q without any references", value, got)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package envy
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GetPercent returns the value of key as a ratio, from 0 to 1, for
+// sampling rates and rollout knobs, see ParsePercent. When key isn't
+// set def is returned, and when its value isn't a valid percentage
+// def is returned with an error.
+func (e *Env) GetPercent(key string, def float64) (float64, error) {
+	v, ok := e.Lookup(key)
+	if !ok {
+		return def, nil
+	}
+	p, err := ParsePercent(v)
+	if err != nil {
+		return def, fmt.Errorf("%s: %w", key, err)
+	}
+	return p, nil
+}
+
+// GetPercent returns the value of key in envy as a ratio, from 0 to 1.
+func GetPercent(key string, def float64) (float64, error) {
+	return env.GetPercent(key, def)
+}
+
+// ParsePercent parses s as a ratio, from 0 to 1. It's either a
+// percentage, such as 25%, a ratio, such as 0.25, or, when it's
+// greater than 1, a percentage without its percent sign, such as 25.
+// An error is returned for anything outside of 0% to 100%.
+func ParsePercent(s string) (float64, error) {
+	v := strings.TrimSpace(s)
+	pct := strings.HasSuffix(v, "%")
+	v = strings.TrimSpace(strings.TrimSuffix(v, "%"))
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("not a valid percentage: %q", s)
+	}
+	if pct || n > 1 {
+		n /= 100
+	}
+	if n < 0 || n > 1 {
+		return 0, fmt.Errorf("not a percentage from 0 to 100: %q", s)
+	}
+	return n, nil
+}
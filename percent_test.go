@@ -0,0 +1,51 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParsePercent(t *testing.T) {
+	r := require.New(t)
+
+	for in, want := range map[string]float64{
+		"25%":   0.25,
+		" 25 %": 0.25,
+		"0.25":  0.25,
+		"25":    0.25,
+		"100":   1,
+		"100%":  1,
+		"1":     1,
+		"0":     0,
+		"0%":    0,
+		"0.5%":  0.005,
+	} {
+		p, err := ParsePercent(in)
+		r.NoError(err, in)
+		r.InDelta(want, p, 1e-9, in)
+	}
+
+	for _, in := range []string{"", "%", "abc", "101", "150%", "-1", "-5%", "NaN", "Inf"} {
+		_, err := ParsePercent(in)
+		r.Error(err, in)
+	}
+}
+
+func Test_Env_GetPercent(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"SAMPLE_RATE": "10%", "BAD": "200%"})
+
+	p, err := e.GetPercent("SAMPLE_RATE", 1)
+	r.NoError(err)
+	r.InDelta(0.1, p, 1e-9)
+
+	p, err = e.GetPercent("MISSING", 0.5)
+	r.NoError(err)
+	r.Equal(0.5, p)
+
+	p, err = e.GetPercent("BAD", 0.5)
+	r.EqualError(err, `BAD: not a percentage from 0 to 100: "200%"`)
+	r.Equal(0.5, p)
+}
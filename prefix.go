@@ -0,0 +1,79 @@
+package envy
+
+import "strings"
+
+// PrefixEnv is a view of the keys of an Env that start with a prefix,
+// such as "MYAPP_", which is stripped from, and added to, the keys it
+// operates on, so libraries can claim a namespace, and be handed a
+// scoped view of the Env: Get("PORT", "") reads MYAPP_PORT.
+type PrefixEnv struct {
+	env    *Env
+	prefix string
+}
+
+// Prefixed returns a view of the keys of the Env starting with prefix.
+func (e *Env) Prefixed(prefix string) PrefixEnv {
+	return PrefixEnv{env: e, prefix: prefix}
+}
+
+// Prefixed returns a view of the keys of envy starting with prefix.
+func Prefixed(prefix string) PrefixEnv {
+	return env.Prefixed(prefix)
+}
+
+// Prefix returns the prefix of the view.
+func (p PrefixEnv) Prefix() string {
+	return p.prefix
+}
+
+// Prefixed returns a view of the keys of the view starting
+// with prefix, the keys of the Env starting with both.
+func (p PrefixEnv) Prefixed(prefix string) PrefixEnv {
+	return PrefixEnv{env: p.env, prefix: p.prefix + prefix}
+}
+
+// Get the value of the prefixed key. If it doesn't
+// exist the default value will be returned.
+func (p PrefixEnv) Get(key string, value string) string {
+	return p.env.Get(p.prefix+key, value)
+}
+
+// MustGet the value of the prefixed key. If it doesn't
+// exist an error will be returned.
+func (p PrefixEnv) MustGet(key string) (string, error) {
+	return p.env.MustGet(p.prefix + key)
+}
+
+// Lookup the value of the prefixed key.
+func (p PrefixEnv) Lookup(key string) (string, bool) {
+	return p.env.Lookup(p.prefix + key)
+}
+
+// Set the value of the prefixed key, see Env.Set.
+func (p PrefixEnv) Set(key string, value string) {
+	p.env.Set(p.prefix+key, value)
+}
+
+// Map the keys starting with the prefix, without
+// it, to their values, except the denied ones.
+func (p PrefixEnv) Map() map[string]string {
+	m := map[string]string{}
+	for k, v := range p.env.Map() {
+		if strings.HasPrefix(k, p.prefix) {
+			m[strings.TrimPrefix(k, p.prefix)] = v
+		}
+	}
+	return m
+}
+
+// Unmarshal sets the fields of the struct v points to from the
+// prefixed keys, see Env.UnmarshalWith: a field tagged `envy:"PORT"`
+// is set from MYAPP_PORT.
+func (p PrefixEnv) Unmarshal(v interface{}) error {
+	return p.UnmarshalWith(v, UnmarshalOptions{})
+}
+
+// UnmarshalWith is like Unmarshal, with the given options.
+func (p PrefixEnv) UnmarshalWith(v interface{}, opts UnmarshalOptions) error {
+	return p.env.unmarshal(v, opts, p.prefix)
+}
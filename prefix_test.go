@@ -0,0 +1,54 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Prefixed(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"MYAPP_PORT":    "3000",
+		"MYAPP_DB_HOST": "localhost",
+		"PORT":          "80",
+	})
+	p := e.Prefixed("MYAPP_")
+	r.Equal("MYAPP_", p.Prefix())
+
+	r.Equal("3000", p.Get("PORT", ""))
+	r.Equal("x", p.Get("NOPE", "x"))
+	_, err := p.MustGet("NOPE")
+	r.Error(err)
+
+	p.Set("HOST", "example.com")
+	r.Equal("example.com", e.Get("MYAPP_HOST", ""))
+	r.Equal(map[string]string{
+		"PORT":    "3000",
+		"DB_HOST": "localhost",
+		"HOST":    "example.com",
+	}, p.Map())
+
+	db := p.Prefixed("DB_")
+	v, ok := db.Lookup("HOST")
+	r.True(ok)
+	r.Equal("localhost", v)
+	r.Equal(map[string]string{"HOST": "localhost"}, db.Map())
+}
+
+func Test_PrefixEnv_Unmarshal(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"MYAPP_PORT": "3000", "PORT": "80"})
+
+	var c struct {
+		Port int `envy:"PORT"`
+		DB   struct {
+			Host string `envy:"DB_HOST,required"`
+		}
+	}
+	err := e.Prefixed("MYAPP_").Unmarshal(&c)
+	r.EqualError(err, "MYAPP_DB_HOST: required but not set")
+	r.Equal(3000, c.Port)
+}
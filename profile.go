@@ -0,0 +1,86 @@
+package envy
+
+import (
+	"os"
+	"strings"
+)
+
+// Profile returns the active profile, the value of GO_ENV,
+// defaulting to "development".
+func (e *Env) Profile() string {
+	return e.Get("GO_ENV", "development")
+}
+
+// ProfileFiles returns the files of the profile cascade, in the
+// order they should be loaded: .env, .env.<profile>, and
+// .env.<profile>.local. Files that don't exist are left out.
+func ProfileFiles(profile string) []string {
+	var files []string
+	for _, f := range []string{".env", ".env." + profile, ".env." + profile + ".local"} {
+		if _, err := os.Stat(f); err == nil {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// LoadProfile loads the files of the profile cascade, see
+// ProfileFiles, so later files override the earlier ones.
+// When profile is empty the active profile is used.
+func (e *Env) LoadProfile(profile string) error {
+	if profile == "" {
+		profile = e.Profile()
+	}
+	files := ProfileFiles(profile)
+	if len(files) == 0 {
+		return nil
+	}
+	return e.Load(files...)
+}
+
+// WithProfileValues controls whether the files loaded by the Env may
+// hold values for a single profile, so small projects can keep one
+// .env file instead of one per profile:
+//
+//	LOG_LEVEL=info
+//	LOG_LEVEL[production]=warn
+//	LOG_LEVEL[development]=debug
+//
+// The values of the active profile, see Profile, or the one set by
+// GO_ENV in the same file, replace the plain ones when the file is
+// loaded; those of the other profiles are dropped.
+func WithProfileValues(b bool) Option {
+	return func(e *Env) {
+		e.profileValues = b
+	}
+}
+
+// resolveProfileValues replaces the values of m with
+// those for profile, dropping those for the others.
+func resolveProfileValues(m map[string]string, profile string) map[string]string {
+	if p, ok := m["GO_ENV"]; ok && p != "" {
+		profile = p
+	}
+	x := make(map[string]string, len(m))
+	for k, v := range m {
+		if _, _, ok := profileKey(k); !ok {
+			x[k] = v
+		}
+	}
+	for k, v := range m {
+		if key, p, ok := profileKey(k); ok && p == profile {
+			x[key] = v
+		}
+	}
+	return x
+}
+
+// profileKey splits a key such as LOG_LEVEL[production]
+// into its key and profile.
+func profileKey(k string) (string, string, bool) {
+	i := strings.IndexByte(k, '[')
+	if i <= 0 || !strings.HasSuffix(k, "]") || len(k) < i+3 {
+		return "", "", false
+	}
+	return k[:i], k[i+1 : len(k)-1], true
+}
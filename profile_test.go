@@ -0,0 +1,78 @@
+package envy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadProfile(t *testing.T) {
+	r := require.New(t)
+
+	pwd, err := os.Getwd()
+	r.NoError(err)
+	r.NoError(os.Chdir("test_env"))
+	defer os.Chdir(pwd)
+
+	r.Equal([]string{".env", ".env.profile", ".env.profile.local"}, ProfileFiles("profile"))
+	r.Equal([]string{".env", ".env.prod"}, ProfileFiles("prod"))
+
+	e := New(WithOSEnv(false))
+	r.NoError(e.LoadProfile("profile"))
+	r.Equal("test_env", e.Get("DIR", ""))
+	r.Equal("profile", e.Get("FLAVOUR", ""))
+	r.Equal("true", e.Get("LOCAL", ""))
+
+	e = New(WithOSEnv(false))
+	e.Set("GO_ENV", "prod")
+	r.NoError(e.LoadProfile(""))
+	r.Equal("production", e.Get("FLAVOUR", ""))
+}
+
+func Test_Profile(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false))
+	r.Equal("development", e.Profile())
+	e.Set("GO_ENV", "production")
+	r.Equal("production", e.Profile())
+}
+
+func Test_WithProfileValues(t *testing.T) {
+	r := require.New(t)
+
+	path := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(path, []byte("LOG_LEVEL[production]=warn\nLOG_LEVEL=info\nLOG_LEVEL[development]=debug\nCACHE[production]=redis\n"), 0644))
+
+	e := New(WithOSEnv(false), WithProfileValues(true))
+	e.Set("GO_ENV", "development")
+	r.NoError(e.Load(path))
+	r.Equal(map[string]string{"GO_ENV": "development", "LOG_LEVEL": "debug"}, e.Map())
+
+	e = New(WithOSEnv(false), WithProfileValues(true), WithLoader(NativeLoader{}))
+	e.Set("GO_ENV", "production")
+	r.NoError(e.Load(path))
+	r.Equal("warn", e.Get("LOG_LEVEL", ""))
+	r.Equal("redis", e.Get("CACHE", ""))
+
+	e = New(WithOSEnv(false), WithProfileValues(true))
+	e.Set("GO_ENV", "test")
+	r.NoError(e.Load(path))
+	r.Equal("info", e.Get("LOG_LEVEL", ""))
+
+	// set in the same file
+	r.NoError(ioutil.WriteFile(path, []byte("GO_ENV=production\nLOG_LEVEL[production]=warn\n"), 0644))
+	e = New(WithOSEnv(false), WithProfileValues(true))
+	r.NoError(e.Load(path))
+	r.Equal("warn", e.Get("LOG_LEVEL", ""))
+
+	// off by default, when the key is invalid
+	e = New(WithOSEnv(false))
+	r.Error(e.Load(path))
+	e = New(WithOSEnv(false), WithRelaxedKeys(true))
+	r.NoError(e.Load(path))
+	r.Equal("warn", e.Get("LOG_LEVEL[production]", ""))
+}
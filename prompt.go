@@ -0,0 +1,174 @@
+package envy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// PromptOptions configures Prompt.
+type PromptOptions struct {
+	// In is where the answers are read from, os.Stdin by default.
+	In io.Reader
+	// Out is where the questions are written to, os.Stderr by default.
+	Out io.Writer
+	// File the answers are offered to be saved to, .env.local by
+	// default, see UpdateFile. Use "-" to not offer to save them.
+	File string
+}
+
+// Prompt asks for the values of the required keys of s that aren't
+// set, when stdin, or opts.In, is a terminal, so a developer checking
+// an app out for the first time is walked through its configuration,
+// rather than handed a list of errors. It does nothing otherwise, such
+// as in CI and production, where Validate reports the missing keys.
+//
+//	if err := envy.Prompt(schema, envy.PromptOptions{}); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Answers are checked against their Spec, asked again when they're
+// invalid, and typed without being echoed for the secrets. They're
+// loaded like the values of a .env file, with "prompt" as its source,
+// then offered to be saved to opts.File, so they're only asked once.
+// When the input ends before a key is answered, an ErrKeyNotFound is
+// returned for it.
+func (e *Env) Prompt(s Schema, opts PromptOptions) error {
+	if opts.In == nil {
+		opts.In = os.Stdin
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stderr
+	}
+	if opts.File == "" {
+		opts.File = ".env.local"
+	}
+	fd := -1
+	if f, ok := opts.In.(*os.File); ok {
+		if !term.IsTerminal(int(f.Fd())) {
+			return nil
+		}
+		fd = int(f.Fd())
+	}
+
+	missing := e.MissingKeys(s)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	p := prompter{in: bufio.NewReader(opts.In), out: opts.Out, fd: fd}
+	answers := map[string]string{}
+	for _, k := range missing {
+		v, err := p.ask(k, s[k], s[k].Secret || e.IsSecret(k))
+		if err != nil {
+			return err
+		}
+		answers[k] = v
+	}
+	if err := e.apply("prompt", answers); err != nil {
+		return err
+	}
+
+	if opts.File == "-" {
+		return nil
+	}
+	yes, err := p.confirm(fmt.Sprintf("Save to %s? [y/N] ", opts.File))
+	if err != nil || !yes {
+		return err
+	}
+	return UpdateFile(opts.File, answers)
+}
+
+// Prompt asks for the values of the required keys of s that
+// aren't set in envy, when stdin is a terminal.
+func Prompt(s Schema, opts PromptOptions) error {
+	return env.Prompt(s, opts)
+}
+
+// MissingKeys returns the sorted required keys of s that aren't set,
+// or, depending on the EmptyPolicy of the Env, are set to an empty
+// value, the keys Prompt asks for.
+func (e *Env) MissingKeys(s Schema) []string {
+	var missing []string
+	for _, k := range s.Keys() {
+		if _, ok := e.Lookup(k); s[k].Required && !ok {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
+
+// MissingKeys returns the sorted required keys of s that aren't set in envy.
+func MissingKeys(s Schema) []string {
+	return env.MissingKeys(s)
+}
+
+// prompter reads the answers to Prompt.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	// fd of the terminal answers are read from, or -1.
+	fd int
+}
+
+// ask for the value of key until a valid one is given.
+func (p prompter) ask(key string, sp Spec, secret bool) (string, error) {
+	q := key
+	if sp.Description != "" {
+		q += " (" + sp.Description + ")"
+	}
+	for {
+		fmt.Fprintf(p.out, "%s: ", q)
+		v, err := p.read(secret)
+		if err == io.EOF {
+			fmt.Fprintln(p.out)
+			return "", ErrKeyNotFound{Key: key}
+		}
+		if err != nil {
+			return "", err
+		}
+		if v == "" {
+			fmt.Fprintf(p.out, "  %s is required\n", key)
+			continue
+		}
+		if err := sp.check(v); err != nil {
+			fmt.Fprintf(p.out, "  %s\n", err)
+			continue
+		}
+		return v, nil
+	}
+}
+
+// confirm asks q, reporting whether it was answered yes.
+func (p prompter) confirm(q string) (bool, error) {
+	fmt.Fprint(p.out, q)
+	v, err := p.read(false)
+	if err == io.EOF {
+		fmt.Fprintln(p.out)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	v = strings.ToLower(v)
+	return v == "y" || v == "yes", nil
+}
+
+// read a line, without echoing it when it's secret
+// and read from a terminal.
+func (p prompter) read(secret bool) (string, error) {
+	if secret && p.fd != -1 {
+		b, err := term.ReadPassword(p.fd)
+		fmt.Fprintln(p.out)
+		return strings.TrimSpace(string(b)), err
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
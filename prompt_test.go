@@ -0,0 +1,88 @@
+package envy
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Prompt(t *testing.T) {
+	r := require.New(t)
+
+	s := Schema{
+		"DATABASE_URL": {Description: "the database to connect to", Required: true, Type: "url"},
+		"API_KEY":      {Required: true, Secret: true},
+		"PORT":         {Required: true, Type: "int"},
+		"LOG_LEVEL":    {},
+	}
+	e := FromMap(map[string]string{"PORT": "3000"})
+	f := filepath.Join(t.TempDir(), ".env.local")
+	out := &bytes.Buffer{}
+	in := strings.NewReader("s3cr3t\n\nlocalhost\npostgres://localhost/app\ny\n")
+
+	r.NoError(e.Prompt(s, PromptOptions{In: in, Out: out, File: f}))
+	r.Equal("s3cr3t", e.Get("API_KEY", ""))
+	r.Equal("postgres://localhost/app", e.Get("DATABASE_URL", ""))
+	// the answers aren't echoed, as the input isn't a terminal
+	r.Equal("API_KEY: "+
+		"DATABASE_URL (the database to connect to):   DATABASE_URL is required\n"+
+		"DATABASE_URL (the database to connect to):   not a valid url\n"+
+		"DATABASE_URL (the database to connect to): "+
+		"Save to "+f+"? [y/N] ", out.String())
+
+	b, err := ioutil.ReadFile(f)
+	r.NoError(err)
+	r.Equal("API_KEY=s3cr3t\nDATABASE_URL=postgres://localhost/app\n", string(b))
+
+	// nothing is asked once they're set
+	out.Reset()
+	r.NoError(e.Prompt(s, PromptOptions{In: strings.NewReader(""), Out: out, File: f}))
+	r.Empty(out.String())
+}
+
+func Test_Env_MissingKeys(t *testing.T) {
+	r := require.New(t)
+
+	s := Schema{
+		"A": {Required: true},
+		"B": {Required: true},
+		"C": {Required: true},
+		"D": {},
+	}
+	vars := map[string]string{"A": "a", "B": ""}
+	r.Equal([]string{"C"}, FromMap(vars).MissingKeys(s))
+
+	e := New(WithOSEnv(false), WithEmptyPolicy(EmptyIsUnset))
+	for k, v := range vars {
+		e.Set(k, v)
+	}
+	r.Equal([]string{"B", "C"}, e.MissingKeys(s))
+}
+
+func Test_Env_Prompt_EOF(t *testing.T) {
+	r := require.New(t)
+
+	s := Schema{"API_KEY": {Required: true}}
+	e := FromMap(nil)
+	err := e.Prompt(s, PromptOptions{In: strings.NewReader(""), Out: &bytes.Buffer{}, File: "-"})
+	r.True(errors.Is(err, ErrKeyNotFound{Key: "API_KEY"}))
+}
+
+func Test_Env_Prompt_NotTerminal(t *testing.T) {
+	r := require.New(t)
+
+	f, err := os.Open(os.DevNull)
+	r.NoError(err)
+	defer f.Close()
+
+	s := Schema{"API_KEY": {Required: true}}
+	out := &bytes.Buffer{}
+	r.NoError(FromMap(nil).Prompt(s, PromptOptions{In: f, Out: out}))
+	r.Empty(out.String())
+}
@@ -0,0 +1,105 @@
+package envy
+
+import (
+	"sort"
+	"sync"
+)
+
+// Recorder records the keys read from an Env through Get and
+// MustGet, so tests can assert code reads exactly the configuration
+// it's documented to.
+type Recorder struct {
+	env      *Env
+	moot     *sync.Mutex
+	accessed map[string]struct{}
+	misses   map[string]struct{}
+}
+
+// Record starts recording the keys read from the Env. Recording
+// continues until Stop is called on the returned Recorder.
+func (e *Env) Record() *Recorder {
+	r := &Recorder{
+		env:      e,
+		moot:     &sync.Mutex{},
+		accessed: map[string]struct{}{},
+		misses:   map[string]struct{}{},
+	}
+	e.moot.Lock()
+	e.recorders = append(e.recorders, r)
+	e.moot.Unlock()
+	return r
+}
+
+// Stop recording. The keys recorded so far are kept.
+func (r *Recorder) Stop() {
+	e := r.env
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	for i, x := range e.recorders {
+		if x == r {
+			// copy, so the slices handed to access are never modified
+			e.recorders = append(e.recorders[:i:i], e.recorders[i+1:]...)
+			break
+		}
+	}
+}
+
+// Accessed returns the sorted keys that were read, whether
+// or not they were found.
+func (r *Recorder) Accessed() []string {
+	r.moot.Lock()
+	defer r.moot.Unlock()
+	return sortedKeys(r.accessed)
+}
+
+// Misses returns the sorted keys that were read but not found.
+func (r *Recorder) Misses() []string {
+	r.moot.Lock()
+	defer r.moot.Unlock()
+	return sortedKeys(r.misses)
+}
+
+func (r *Recorder) record(key string, found bool) {
+	r.moot.Lock()
+	defer r.moot.Unlock()
+	r.accessed[key] = struct{}{}
+	if !found {
+		r.misses[key] = struct{}{}
+	}
+}
+
+// access is called every time a key is read from the Env.
+func (e *Env) access(key string, found bool) {
+	e.moot.RLock()
+	recorders := e.recorders
+	hooks := e.accessHooks
+	m := e.metrics
+	e.moot.RUnlock()
+
+	e.track(key, found)
+	if found {
+		m.Hit(key)
+	} else {
+		m.Miss(key)
+	}
+
+	for _, r := range recorders {
+		r.record(key, found)
+	}
+	if len(hooks) == 0 {
+		return
+	}
+	c := caller()
+	for _, h := range hooks {
+		h(key, found, c)
+	}
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,27 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Record(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"FOO": "foo", "BAR": "bar"})
+	e.Get("FOO", "")
+
+	rec := e.Record()
+	e.Get("FOO", "")
+	e.Get("BAZ", "baz")
+	e.MustGet("QUX")
+	e.Map()
+
+	r.Equal([]string{"BAZ", "FOO", "QUX"}, rec.Accessed())
+	r.Equal([]string{"BAZ", "QUX"}, rec.Misses())
+
+	rec.Stop()
+	e.Get("BAR", "")
+	r.Equal([]string{"BAZ", "FOO", "QUX"}, rec.Accessed())
+}
@@ -0,0 +1,63 @@
+package envy
+
+import "errors"
+
+var errNoRegistry = errors.New("only available on Windows")
+
+// WithRegistry controls whether New also loads the user and system
+// environment persisted in the Windows registry, see LoadRegistry,
+// so the variables set since the process started, such as by
+// PersistSet in another process, are visible. It has no effect on
+// other platforms.
+func WithRegistry(b bool) Option {
+	return func(e *Env) {
+		e.registry = b
+	}
+}
+
+// LoadRegistry loads the environment persisted in the Windows
+// registry: the system variables, then the user variables, which
+// override them, except Path, which the user's is appended to, like
+// Windows does when it creates a process. Values referencing other
+// variables, like %USERPROFILE%, are expanded. Like Load, an Env
+// bound to the OS also sets them in the OS environment, and is
+// reloaded, keeping its settings, see WithSettings. An error is
+// returned on other platforms.
+func (e *Env) LoadRegistry() (err error) {
+	defer func() {
+		e.setStatus("registry", err)
+	}()
+	m, err := readRegistry()
+	if err != nil {
+		return withSource("registry", err)
+	}
+	// the names of Windows, such as ProgramFiles(x86), aren't POSIX
+	return withSource("registry", e.applyKeys("registry", m, true))
+}
+
+// LoadRegistry loads the environment persisted
+// in the Windows registry into envy.
+func LoadRegistry() error {
+	return env.LoadRegistry()
+}
+
+// PersistSet sets the value with MustSet, and persists it in the user
+// environment of the Windows registry, so it outlives the process, as
+// os.Setenv doesn't. The running programs, such as Explorer, are
+// notified of the change, so the processes they start see it. On
+// other platforms, an error is returned, and the value isn't set.
+func (e *Env) PersistSet(key string, value string) error {
+	if !hasRegistry {
+		return withSource("registry", errNoRegistry)
+	}
+	if err := e.MustSet(key, value); err != nil {
+		return err
+	}
+	return withSource("registry", persistRegistry(key, value))
+}
+
+// PersistSet sets the value in envy, and persists
+// it in the user environment of the Windows registry.
+func PersistSet(key string, value string) error {
+	return env.PersistSet(key, value)
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package envy
+
+// hasRegistry reports whether the platform has a registry to
+// persist the environment in, see PersistSet.
+const hasRegistry = false
+
+func readRegistry() (map[string]string, error) {
+	return nil, errNoRegistry
+}
+
+func persistRegistry(key string, value string) error {
+	return errNoRegistry
+}
@@ -0,0 +1,28 @@
+package envy
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_LoadRegistry(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("reads the registry of the host")
+	}
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithRegistry(true))
+	err := e.LoadRegistry()
+	r.EqualError(err, "registry: only available on Windows")
+	sts := e.Statuses()
+	r.Len(sts, 1)
+	r.Equal("registry", sts[0].Source)
+	r.Equal("registry: only available on Windows", sts[0].Error)
+
+	err = e.PersistSet("FOO", "bar")
+	r.EqualError(err, "registry: only available on Windows")
+	_, ok := e.Lookup("FOO")
+	r.False(ok)
+}
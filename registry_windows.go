@@ -0,0 +1,98 @@
+//go:build windows
+
+package envy
+
+import (
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+const hasRegistry = true
+
+const systemEnvironment = `SYSTEM\CurrentControlSet\Control\Session Manager\Environment`
+
+const (
+	hwndBroadcast   = 0xffff
+	wmSettingChange = 0x001a
+	smtoAbortIfHung = 0x0002
+)
+
+var sendMessageTimeout = windows.NewLazySystemDLL("user32.dll").NewProc("SendMessageTimeoutW")
+
+func readRegistry() (map[string]string, error) {
+	m := map[string]string{}
+	if err := readRegistryKey(registry.LOCAL_MACHINE, systemEnvironment, m); err != nil {
+		return nil, err
+	}
+	if err := readRegistryKey(registry.CURRENT_USER, "Environment", m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// readRegistryKey reads the string values of the key at path into m.
+func readRegistryKey(root registry.Key, path string, m map[string]string) error {
+	k, err := registry.OpenKey(root, path, registry.QUERY_VALUE)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+
+	names, err := k.ReadValueNames(0)
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		v, typ, err := k.GetStringValue(n)
+		if err != nil {
+			// not a string
+			continue
+		}
+		if typ == registry.EXPAND_SZ {
+			if x, err := registry.ExpandString(v); err == nil {
+				v = x
+			}
+		}
+
+		// the keys are case-insensitive
+		for o, ov := range m {
+			if strings.EqualFold(o, n) {
+				delete(m, o)
+				if strings.EqualFold(n, "Path") && ov != "" {
+					v = ov + ";" + v
+				}
+				break
+			}
+		}
+		m[n] = v
+	}
+	return nil
+}
+
+func persistRegistry(key string, value string) error {
+	k, err := registry.OpenKey(registry.CURRENT_USER, "Environment", registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+
+	if strings.Contains(value, "%") {
+		err = k.SetExpandStringValue(key, value)
+	} else {
+		err = k.SetStringValue(key, value)
+	}
+	if err != nil {
+		return err
+	}
+
+	env, err := windows.UTF16PtrFromString("Environment")
+	if err != nil {
+		return err
+	}
+	// tell the running programs, without waiting on the hung ones
+	sendMessageTimeout.Call(hwndBroadcast, wmSettingChange, 0, uintptr(unsafe.Pointer(env)), smtoAbortIfHung, 5000, 0)
+	return nil
+}
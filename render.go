@@ -0,0 +1,125 @@
+package envy
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"text/template"
+)
+
+// RenderOptions control how RenderTemplateWith renders templates.
+type RenderOptions struct {
+	// Name of the template, used in the error messages.
+	Name string
+	// Strict fails on references to keys that aren't set,
+	// such as {{ .NOPE }}, rather than rendering "<no value>".
+	Strict bool
+	// Helpers adds the string helpers of HelperFuncs.
+	Helpers bool
+}
+
+// RenderTemplate renders the text/template read from in to out, with
+// the variables of the Env, and its pseudo-variables, see
+// WithRuntimeVars, as its data, {{ .PORT }}, and the functions of
+// FuncMap, so apps can materialize configuration files, such as the
+// one of nginx, from the Env. It's the library counterpart of the
+// template command of the envy CLI.
+func (e *Env) RenderTemplate(in io.Reader, out io.Writer) error {
+	return e.RenderTemplateWith(in, out, RenderOptions{})
+}
+
+// RenderTemplate renders the text/template read from in to out with envy.
+func RenderTemplate(in io.Reader, out io.Writer) error {
+	return env.RenderTemplate(in, out)
+}
+
+// RenderTemplateWith is like RenderTemplate, with the given options.
+func (e *Env) RenderTemplateWith(in io.Reader, out io.Writer, opts RenderOptions) error {
+	b, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = "template"
+	}
+	t := template.New(name).Funcs(e.FuncMap())
+	if opts.Helpers {
+		t = t.Funcs(HelperFuncs())
+	}
+	if opts.Strict {
+		t = t.Option("missingkey=error")
+	}
+	if t, err = t.Parse(string(b)); err != nil {
+		return err
+	}
+	return t.Execute(out, e.withRuntimeVars(e.Map()))
+}
+
+// HelperFuncs returns string helpers for templates, like those of
+// sprig, the values they're applied to coming last, so they can be
+// piped: {{ env "HOST" | default "localhost" | quote }}.
+//
+//	default "d" v        v, or d when v is empty
+//	upper v, lower v     v in upper, or lower, case
+//	trim v               v without its leading and trailing spaces
+//	quote v, squote v    v in double, or single, quotes
+//	replace "a" "b" v    v with every a replaced by b
+//	split "," v          the parts of v separated by ,
+//	join "," list        the items of list joined with ,
+//	indent 4 v           v with every line indented by 4 spaces
+//	b64enc v, b64dec v   v encoded to, or decoded from, base64
+//	contains "a" v       whether v contains a
+//	hasPrefix "a" v      whether v starts with a
+//	hasSuffix "a" v      whether v ends with a
+func HelperFuncs() template.FuncMap {
+	return template.FuncMap{
+		"default": func(def string, v string) string {
+			if v == "" {
+				return def
+			}
+			return v
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"quote": func(v string) string {
+			return fmt.Sprintf("%q", v)
+		},
+		"squote": func(v string) string {
+			return "'" + v + "'"
+		},
+		"replace": func(old, new, v string) string {
+			return strings.ReplaceAll(v, old, new)
+		},
+		"split": func(sep, v string) []string {
+			return strings.Split(v, sep)
+		},
+		"join": func(sep string, list []string) string {
+			return strings.Join(list, sep)
+		},
+		"indent": func(n int, v string) string {
+			pad := strings.Repeat(" ", n)
+			return pad + strings.ReplaceAll(v, "\n", "\n"+pad)
+		},
+		"b64enc": func(v string) string {
+			return base64.StdEncoding.EncodeToString([]byte(v))
+		},
+		"b64dec": func(v string) (string, error) {
+			b, err := base64.StdEncoding.DecodeString(v)
+			return string(b), err
+		},
+		"contains": func(sub, v string) bool {
+			return strings.Contains(v, sub)
+		},
+		"hasPrefix": func(prefix, v string) bool {
+			return strings.HasPrefix(v, prefix)
+		},
+		"hasSuffix": func(suffix, v string) bool {
+			return strings.HasSuffix(v, suffix)
+		},
+	}
+}
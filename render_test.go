@@ -0,0 +1,42 @@
+package envy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_RenderTemplate(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"HOST": "example.com", "PORT": "8080"})
+
+	bb := &bytes.Buffer{}
+	in := strings.NewReader(`listen {{ .PORT }}; server_name {{ env "HOST" }}; {{ .NOPE }}`)
+	r.NoError(e.RenderTemplate(in, bb))
+	r.Equal("listen 8080; server_name example.com; <no value>", bb.String())
+
+	bb.Reset()
+	in = strings.NewReader(`{{ .NOPE }}`)
+	err := e.RenderTemplateWith(in, bb, RenderOptions{Name: "nginx.conf", Strict: true})
+	r.Error(err)
+	r.Contains(err.Error(), "nginx.conf")
+
+	// the helpers are opt-in
+	in = strings.NewReader(`{{ upper .HOST }}`)
+	r.Error(e.RenderTemplate(in, bb))
+}
+
+func Test_HelperFuncs(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"HOST": "example.com", "HOSTS": "a,b"})
+	tmpl := `{{ env "NOPE" | default "localhost" | quote }} {{ .HOST | upper }} {{ split "," .HOSTS | join ";" }} {{ b64enc "hi" | b64dec }} {{ if hasSuffix ".com" .HOST }}com{{ end }}
+{{ "a\nb" | indent 2 }}`
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.RenderTemplateWith(strings.NewReader(tmpl), bb, RenderOptions{Helpers: true}))
+	r.Equal("\"localhost\" EXAMPLE.COM a;b hi com\n  a\n  b", bb.String())
+}
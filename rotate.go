@@ -0,0 +1,23 @@
+package envy
+
+type rotateHook struct {
+	pattern string
+	fn      func(key, old, new string)
+}
+
+// OnRotate registers fn to be called when the value of a secret
+// whose key matches the glob pattern, see path.Match, changes, so
+// clients using the old credentials can be rebuilt. It fires for
+// changes made by Set, MustSet, Load, and Reload, after the new
+// value is visible, and only for keys that had a value before.
+func (e *Env) OnRotate(pattern string, fn func(key, old, new string)) {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.rotateHooks = append(e.rotateHooks, rotateHook{pattern: pattern, fn: fn})
+}
+
+// OnRotate registers fn to be called when the value of
+// a secret whose key matches pattern changes.
+func OnRotate(pattern string, fn func(key, old, new string)) {
+	env.OnRotate(pattern, fn)
+}
@@ -0,0 +1,74 @@
+package envy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_OnRotate(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"API_TOKEN": "one",
+		"DB_PASS":   "pass",
+		"PORT":      "3000",
+	})
+
+	var rotations []string
+	e.OnRotate("*", func(key, old, new string) {
+		// the new value must already be visible
+		r.Equal(new, e.Get(key, ""))
+		rotations = append(rotations, key+":"+old+"->"+new)
+	})
+
+	e.Set("API_TOKEN", "two")
+	e.Set("API_TOKEN", "two")
+	e.Set("PORT", "4000")
+	e.Set("DB_PASS", "other")
+	e.Set("NEW_TOKEN", "new")
+	r.NoError(e.MustSet("API_TOKEN", "three"))
+
+	e.MarkSecret("DB_PASS")
+	e.Set("DB_PASS", "rotated")
+
+	r.Equal([]string{
+		"API_TOKEN:one->two",
+		"API_TOKEN:two->three",
+		"DB_PASS:other->rotated",
+	}, rotations)
+}
+
+func Test_Env_OnRotate_Load(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	f := filepath.Join(dir, ".env")
+	r.NoError(ioutil.WriteFile(f, []byte("API_TOKEN=two\nAPP_SECRET=new\n"), 0644))
+
+	e := FromMap(map[string]string{"API_TOKEN": "one"})
+	var rotations []string
+	e.OnRotate("API_*", func(key, old, new string) {
+		rotations = append(rotations, key+":"+old+"->"+new)
+	})
+	r.NoError(e.Load(f))
+	r.Equal([]string{"API_TOKEN:one->two"}, rotations)
+}
+
+func Test_Env_OnRotate_Reload(t *testing.T) {
+	r := require.New(t)
+
+	t.Setenv("ENVY_ROTATE_TOKEN", "one")
+	e := New()
+	var rotations []string
+	e.OnRotate("ENVY_*", func(key, old, new string) {
+		rotations = append(rotations, key+":"+old+"->"+new)
+	})
+
+	os.Setenv("ENVY_ROTATE_TOKEN", "two")
+	e.Reload()
+	r.Equal([]string{"ENVY_ROTATE_TOKEN:one->two"}, rotations)
+}
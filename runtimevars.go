@@ -0,0 +1,69 @@
+package envy
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// startedAt is about when the process started.
+var startedAt = time.Now()
+
+// pseudoVars are the pseudo-variables of the runtime, see WithRuntimeVars.
+var pseudoVars = map[string]func() string{
+	"ENVY_HOSTNAME": func() string {
+		h, _ := os.Hostname()
+		return h
+	},
+	"ENVY_PID":        func() string { return strconv.Itoa(os.Getpid()) },
+	"ENVY_GOOS":       func() string { return runtime.GOOS },
+	"ENVY_GOARCH":     func() string { return runtime.GOARCH },
+	"ENVY_NUM_CPU":    func() string { return strconv.Itoa(runtime.NumCPU()) },
+	"ENVY_STARTED_AT": func() string { return startedAt.Format(time.RFC3339) },
+}
+
+// WithRuntimeVars controls whether the Env provides pseudo-variables
+// for facts about the runtime, so templates and derived values, see
+// Derive, can reference them:
+//
+//	ENVY_HOSTNAME    the host name, see os.Hostname
+//	ENVY_PID         the process id
+//	ENVY_GOOS        runtime.GOOS
+//	ENVY_GOARCH      runtime.GOARCH
+//	ENVY_NUM_CPU     runtime.NumCPU
+//	ENVY_STARTED_AT  when the process started, in RFC 3339 format
+//
+// They're read with Get, MustGet, Lookup, and the functions built on
+// them, unless a variable of the same name is set, and, as they're
+// not set, aren't returned by Map or Environ. They're off by default,
+// so an Env, such as one returned by FromMap, only holds the variables
+// it's given, and Clone keeps the setting of the Env.
+func WithRuntimeVars(b bool) Option {
+	return func(e *Env) {
+		e.runtimeVars = b
+	}
+}
+
+// runtimeVar returns the value of the pseudo-variable key.
+func (e *Env) runtimeVar(key string) (string, bool) {
+	fn, ok := pseudoVars[key]
+	if !ok || !e.runtimeVars {
+		return "", false
+	}
+	return fn(), true
+}
+
+// withRuntimeVars returns m with the pseudo-variables
+// that aren't set in it.
+func (e *Env) withRuntimeVars(m map[string]string) map[string]string {
+	if !e.runtimeVars {
+		return m
+	}
+	for k, fn := range pseudoVars {
+		if _, ok := m[k]; !ok {
+			m[k] = fn()
+		}
+	}
+	return m
+}
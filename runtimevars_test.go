@@ -0,0 +1,55 @@
+package envy
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_RuntimeVars(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithRuntimeVars(true))
+	e.Set("ENVY_GOOS", "plan9")
+	r.Equal("plan9", e.Get("ENVY_GOOS", ""))
+	r.Equal(runtime.GOARCH, e.Get("ENVY_GOARCH", ""))
+	r.Equal(strconv.Itoa(os.Getpid()), e.Get("ENVY_PID", ""))
+	r.Equal(strconv.Itoa(runtime.NumCPU()), e.Get("ENVY_NUM_CPU", ""))
+	h, _ := os.Hostname()
+	r.Equal(h, e.Get("ENVY_HOSTNAME", ""))
+
+	v, err := e.MustGet("ENVY_STARTED_AT")
+	r.NoError(err)
+	at, err := time.Parse(time.RFC3339, v)
+	r.NoError(err)
+	r.False(at.After(time.Now()))
+
+	r.Equal(map[string]string{"ENVY_GOOS": "plan9"}, e.Map())
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.RenderTemplate(strings.NewReader(`{{ .ENVY_GOOS }}/{{ .ENVY_GOARCH }}`), bb))
+	r.Equal("plan9/"+runtime.GOARCH, bb.String())
+
+	_, ok := e.Clone().Lookup("ENVY_PID")
+	r.True(ok)
+}
+
+func Test_Env_RuntimeVars_Off(t *testing.T) {
+	r := require.New(t)
+
+	// off by default, so FromMap, and the Envs not bound
+	// to the OS, only hold the variables they're given
+	for _, e := range []*Env{New(), New(WithOSEnv(false)), FromMap(map[string]string{"PORT": "3000"})} {
+		_, ok := e.Lookup("ENVY_PID")
+		r.False(ok)
+		_, ok = e.Clone().Lookup("ENVY_PID")
+		r.False(ok)
+	}
+	r.Equal(map[string]string{"PORT": "3000"}, FromMap(map[string]string{"PORT": "3000"}).Clone().Map())
+}
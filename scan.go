@@ -0,0 +1,144 @@
+package envy
+
+import (
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Finding is a value that looks like a live credential.
+type Finding struct {
+	Key string
+	// Reason the value was flagged, such as "high entropy value".
+	Reason string
+}
+
+// credentials are the well known formats of credentials.
+var credentials = []struct {
+	name string
+	rx   *regexp.Regexp
+}{
+	{"AWS access key", regexp.MustCompile(`^(AKIA|ASIA)[0-9A-Z]{16}$`)},
+	{"GitHub token", regexp.MustCompile(`^(ghp|gho|ghu|ghs|ghr|github_pat)_[0-9A-Za-z_]{20,}$`)},
+	{"GitLab token", regexp.MustCompile(`^glpat-[0-9A-Za-z_-]{20}$`)},
+	{"Google API key", regexp.MustCompile(`^AIza[0-9A-Za-z_-]{35}$`)},
+	{"JSON Web Token", regexp.MustCompile(`^eyJ[0-9A-Za-z_-]+\.eyJ[0-9A-Za-z_-]+\.[0-9A-Za-z_-]+$`)},
+	{"npm token", regexp.MustCompile(`^npm_[0-9A-Za-z]{36}$`)},
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY( BLOCK)?-----`)},
+	{"Slack token", regexp.MustCompile(`^xox[abposr]-[0-9A-Za-z-]{10,}$`)},
+	{"Stripe live key", regexp.MustCompile(`^(sk|rk)_live_[0-9A-Za-z]{16,}$`)},
+}
+
+// secretWords are the words, delimited by underscores, of the keys
+// that usually hold credentials, see DefaultSecretPatterns.
+var secretWords = []string{
+	"TOKEN", "SECRET", "PASSWORD", "PASSWD", "API_KEY", "APIKEY",
+	"PRIVATE_KEY", "ACCESS_KEY", "CREDENTIAL", "AUTH",
+}
+
+// placeholders are the values commonly used in examples.
+var placeholders = []string{
+	"changeme", "change_me", "example", "placeholder", "secret",
+	"password", "xxx", "todo", "your_", "<", "${",
+}
+
+const (
+	// minEntropy is the Shannon entropy, in bits per
+	// character, above which a value looks random.
+	minEntropy = 4.0
+	// minSecretLen is the length below which a value
+	// is too short to be a credential.
+	minSecretLen = 8
+)
+
+// ScanForSecrets flags the values of the Env that look like live
+// credentials, using the formats of well known credentials, the
+// entropy of the values, and whether their keys are secrets, see
+// IsSecret, for tools keeping secrets out of committed .env files.
+// Encrypted values, see EncryptValue, and obvious placeholders
+// aren't flagged.
+// The findings are sorted by key, and never include the values.
+func (e *Env) ScanForSecrets() []Finding {
+	e.moot.RLock()
+	m := e.all()
+	secrets := map[string]bool{}
+	for k := range m {
+		secrets[k] = e.isSecret(k)
+	}
+	e.moot.RUnlock()
+
+	var findings []Finding
+	for k, v := range m {
+		if reason := scanValue(v, secrets[k]); reason != "" {
+			findings = append(findings, Finding{Key: k, Reason: reason})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Key < findings[j].Key
+	})
+	return findings
+}
+
+// ScanForSecrets flags the values in envy
+// that look like live credentials.
+func ScanForSecrets() []Finding {
+	return env.ScanForSecrets()
+}
+
+// scanValue returns why value, the value of a secret key when
+// secret is true, was flagged, or the empty string.
+func scanValue(value string, secret bool) string {
+	v := strings.TrimSpace(value)
+	if len(v) < minSecretLen || strings.HasPrefix(v, EncryptedPrefix) || isPlaceholder(v) {
+		return ""
+	}
+
+	for _, c := range credentials {
+		if c.rx.MatchString(v) {
+			return c.name
+		}
+	}
+
+	if u, err := url.Parse(v); err == nil && u.Scheme != "" && u.User != nil {
+		if p, ok := u.User.Password(); ok && p != "" && !isPlaceholder(p) {
+			return "URL with a password"
+		}
+	}
+
+	if len(v) >= 20 && !strings.ContainsAny(v, " \t") && entropy(v) >= minEntropy {
+		return "high entropy value"
+	}
+
+	if secret {
+		return "secret key name"
+	}
+	return ""
+}
+
+func isPlaceholder(v string) bool {
+	lv := strings.ToLower(v)
+	for _, p := range placeholders {
+		if strings.HasPrefix(lv, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// entropy returns the Shannon entropy of s, in bits per character.
+func entropy(s string) float64 {
+	counts := map[rune]int{}
+	n := 0
+	for _, c := range s {
+		counts[c]++
+		n++
+	}
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
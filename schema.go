@@ -0,0 +1,143 @@
+package envy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes a single ENV variable in a Schema.
+type Spec struct {
+	// Description of what the variable is used for.
+	Description string `yaml:"description" json:"description"`
+	// Required variables must be set.
+	Required bool `yaml:"required" json:"required"`
+	// Type of the value; one of string (the default), int,
+	// float, bool, duration, url, or percent, see ParsePercent.
+	Type string `yaml:"type" json:"type"`
+	// Pattern is a regular expression the value must match.
+	Pattern string `yaml:"pattern" json:"pattern"`
+	// Secret values must not be displayed.
+	Secret bool `yaml:"secret" json:"secret"`
+}
+
+var schemaTypes = map[string]bool{
+	"": true, "string": true, "int": true, "float": true,
+	"bool": true, "duration": true, "url": true, "percent": true,
+}
+
+// Schema describes the ENV variables an application expects, by key.
+type Schema map[string]Spec
+
+// LoadSchema reads a Schema from a YAML, or JSON, file.
+//
+//	DATABASE_URL:
+//	  description: the database to connect to
+//	  required: true
+//	  type: url
+//	  secret: true
+func LoadSchema(path string) (Schema, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := Schema{}
+	if err := yaml.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("could not parse schema %s: %w", path, err)
+	}
+	for _, k := range s.Keys() {
+		sp := s[k]
+		if !schemaTypes[sp.Type] {
+			return nil, fmt.Errorf("invalid schema %s: %s: unknown type %q", path, k, sp.Type)
+		}
+		if _, err := regexp.Compile(sp.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid schema %s: %s: %w", path, k, err)
+		}
+	}
+	return s, nil
+}
+
+// Keys returns the sorted keys of the Schema.
+func (s Schema) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate the given variables against the Schema, returning an
+// error for every key that is missing or invalid, sorted by key.
+func (s Schema) Validate(vars map[string]string) []error {
+	return s.validate(vars, false)
+}
+
+// validate the variables, reporting the required
+// keys set to an empty value when strict.
+func (s Schema) validate(vars map[string]string, strict bool) []error {
+	var errs []error
+	for _, k := range s.Keys() {
+		sp := s[k]
+		v, ok := vars[k]
+		if !ok {
+			if sp.Required {
+				errs = append(errs, fmt.Errorf("%s: required but not set", k))
+			}
+			continue
+		}
+		if v == "" && sp.Required && strict {
+			errs = append(errs, fmt.Errorf("%s: required but empty", k))
+			continue
+		}
+		if err := sp.check(v); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", k, err))
+		}
+	}
+	return errs
+}
+
+func (sp Spec) check(v string) error {
+	var err error
+	switch sp.Type {
+	case "", "string":
+	case "int":
+		_, err = strconv.Atoi(v)
+	case "float":
+		_, err = strconv.ParseFloat(v, 64)
+	case "bool":
+		_, err = strconv.ParseBool(v)
+	case "duration":
+		_, err = time.ParseDuration(v)
+	case "percent":
+		_, err = ParsePercent(v)
+	case "url":
+		var u *url.URL
+		u, err = url.Parse(v)
+		if err == nil && u.Scheme == "" {
+			err = fmt.Errorf("missing scheme")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", sp.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("not a valid %s", sp.Type)
+	}
+
+	if sp.Pattern != "" {
+		rx, err := regexp.Compile(sp.Pattern)
+		if err != nil {
+			return err
+		}
+		if !rx.MatchString(v) {
+			return fmt.Errorf("does not match %q", sp.Pattern)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadSchema(t *testing.T) {
+	r := require.New(t)
+
+	s, err := LoadSchema("test_env/schema.yml")
+	r.NoError(err)
+	r.Equal([]string{"DATABASE_URL", "DIR", "INSIDE_FOLDER", "PORT"}, s.Keys())
+	r.True(s["DATABASE_URL"].Secret)
+	r.Equal("bool", s["INSIDE_FOLDER"].Type)
+
+	_, err = LoadSchema("test_env/nope.yml")
+	r.Error(err)
+}
+
+func Test_Schema_Validate(t *testing.T) {
+	r := require.New(t)
+
+	s := Schema{
+		"DIR":          {Required: true},
+		"PORT":         {Type: "int"},
+		"DATABASE_URL": {Required: true, Type: "url", Pattern: "^postgres://"},
+		"SAMPLE_RATE":  {Type: "percent"},
+	}
+
+	errs := s.Validate(map[string]string{
+		"DIR":          "root",
+		"DATABASE_URL": "postgres://localhost/db",
+		"SAMPLE_RATE":  "25%",
+	})
+	r.Empty(errs)
+
+	errs = s.Validate(map[string]string{
+		"PORT":         "eighty",
+		"DATABASE_URL": "mysql://localhost/db",
+		"SAMPLE_RATE":  "250%",
+	})
+	r.Len(errs, 4)
+	r.EqualError(errs[0], `DATABASE_URL: does not match "^postgres://"`)
+	r.EqualError(errs[1], "DIR: required but not set")
+	r.EqualError(errs[2], "PORT: not a valid int")
+	r.EqualError(errs[3], "SAMPLE_RATE: not a valid percent")
+}
@@ -0,0 +1,112 @@
+package envy
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// DefaultSecretPatterns are the glob patterns, see path.Match, of the
+// keys every Env treats as secrets, unless told otherwise with
+// SetSecretPatterns: the keys with one of the words TOKEN, SECRET,
+// PASSWORD, PASSWD, API_KEY, APIKEY, PRIVATE_KEY, ACCESS_KEY,
+// CREDENTIAL, or AUTH, or their plurals, delimited by underscores,
+// such as GITHUB_TOKEN and AWS_SECRET_ACCESS_KEY, and the keys ending
+// with _KEY, such as STRIPE_KEY. They're also the keys ScanForSecrets
+// flags by name.
+var DefaultSecretPatterns = append(wordPatterns(secretWords), "*_KEY")
+
+// wordPatterns returns the glob patterns matching the keys with
+// one of words, or its plural, delimited by underscores.
+func wordPatterns(words []string) []string {
+	var patterns []string
+	for _, w := range words {
+		for _, w := range []string{w, w + "S"} {
+			patterns = append(patterns, w, "*_"+w, w+"_*", "*_"+w+"_*")
+		}
+	}
+	return patterns
+}
+
+// MarkSecret marks the given keys as secrets, whose values are
+// redacted by RedactedMap, RedactedEnviron, String, and the
+// serializers when ExportOptions.RedactSecrets is set.
+func (e *Env) MarkSecret(keys ...string) {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	for _, k := range keys {
+		e.secrets[k] = true
+	}
+	e.seal()
+}
+
+// SetSecretPatterns replaces the glob patterns, see path.Match, of
+// the keys automatically treated as secrets. It starts out with
+// DefaultSecretPatterns; calling it with no patterns disables the
+// automatic detection.
+func (e *Env) SetSecretPatterns(patterns ...string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+	}
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.secretPatterns = append([]string{}, patterns...)
+	e.seal()
+	return nil
+}
+
+// IsSecret reports whether key was marked as a secret,
+// or matches one of the secret patterns.
+func (e *Env) IsSecret(key string) bool {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	return e.isSecret(key)
+}
+
+func (e *Env) isSecret(key string) bool {
+	return e.secrets[key] || matchAny(e.secretPatterns, key)
+}
+
+// RedactedMap is like Map, but with the values of
+// the secrets replaced by Redacted.
+func (e *Env) RedactedMap() map[string]string {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	m := e.exported()
+	for k, v := range m {
+		m[k], _ = e.redactValue(k, v)
+	}
+	return m
+}
+
+// redactValue returns v, the value of key, replaced by Redacted when
+// key is a secret, or with the password of its userinfo replaced by
+// Redacted when it's a URL, such as the value of DATABASE_URL, and
+// whether it was redacted. It must be called with e.moot held.
+func (e *Env) redactValue(key string, v string) (string, bool) {
+	if v == "" {
+		return v, false
+	}
+	if e.isSecret(key) {
+		return Redacted, true
+	}
+	u, err := url.Parse(v)
+	if err != nil || u.Scheme == "" || u.User == nil {
+		return v, false
+	}
+	if _, ok := u.User.Password(); !ok {
+		return v, false
+	}
+	u.User = url.UserPassword(u.User.Username(), Redacted)
+	// keep Redacted readable, rather than escaped
+	return strings.ReplaceAll(u.String(), url.PathEscape(Redacted), Redacted), true
+}
+
+// RedactedEnviron is like Environ, but with the values
+// of the secrets replaced by Redacted.
+func (e *Env) RedactedEnviron() []string {
+	return environ(e.RedactedMap())
+}
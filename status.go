@@ -0,0 +1,43 @@
+package envy
+
+import (
+	"sort"
+	"time"
+)
+
+// SourceStatus is the status of a source of variables, such as
+// the OS or a .env file, as of the last time it was loaded.
+type SourceStatus struct {
+	Source string    `json:"source"`
+	Loaded time.Time `json:"loaded"`
+	Error  string    `json:"error,omitempty"`
+}
+
+func newSourceStatus(source string, err error) SourceStatus {
+	st := SourceStatus{Source: source, Loaded: time.Now()}
+	if err != nil {
+		st.Error = err.Error()
+	}
+	return st
+}
+
+func (e *Env) setStatus(source string, err error) {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	e.statuses[source] = newSourceStatus(source, err)
+}
+
+// Statuses returns the status of every source the Env loaded, or
+// tried to load, variables from, by Load and Reload, sorted by source.
+func (e *Env) Statuses() []SourceStatus {
+	e.moot.RLock()
+	defer e.moot.RUnlock()
+	sts := make([]SourceStatus, 0, len(e.statuses))
+	for _, st := range e.statuses {
+		sts = append(sts, st)
+	}
+	sort.Slice(sts, func(i, j int) bool {
+		return sts[i].Source < sts[j].Source
+	})
+	return sts
+}
@@ -0,0 +1,29 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Statuses(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{})
+	r.Empty(e.Statuses())
+
+	r.NoError(e.Load("test_env/.env"))
+	r.Error(e.Load("test_env/.env.nope"))
+
+	sts := e.Statuses()
+	r.Len(sts, 2)
+	r.Equal("test_env/.env", sts[0].Source)
+	r.Empty(sts[0].Error)
+	r.False(sts[0].Loaded.IsZero())
+	r.Equal("test_env/.env.nope", sts[1].Source)
+	r.Contains(sts[1].Error, "no such file or directory")
+
+	e = New()
+	e.Reload()
+	r.Equal("os", e.Statuses()[0].Source)
+}
@@ -0,0 +1,2 @@
+FLAVOUR=profile
+LOCAL=false
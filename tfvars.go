@@ -0,0 +1,51 @@
+package envy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var tfNumberRx = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+
+var tfQuoter = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{")
+
+// ToTFVars writes the keys of the Env starting with prefix to w as
+// Terraform tfvars assignments, sorted by key. The prefix is stripped,
+// and the rest of the key lower cased, to form the variable name:
+// MYAPP_DB_HOST becomes db_host with the MYAPP_ prefix. Values of true
+// and false are written as bools, plain decimal numbers as numbers,
+// and everything else as strings.
+func (e *Env) ToTFVars(w io.Writer, prefix string, opts ExportOptions) error {
+	m, keys, err := e.export(opts)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, k := range keys {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(k, prefix))
+		if !isVarName(strings.ReplaceAll(name, "-", "_")) {
+			return fmt.Errorf("%s can't be used as a terraform variable name", name)
+		}
+		lines = append(lines, fmt.Sprintf("%s = %s", name, tfValue(m[k])))
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, l := range lines {
+		fmt.Fprintln(bw, l)
+	}
+	return bw.Flush()
+}
+
+func tfValue(v string) string {
+	if v == "true" || v == "false" || tfNumberRx.MatchString(v) {
+		return v
+	}
+	return `"` + tfQuoter.Replace(v) + `"`
+}
@@ -0,0 +1,37 @@
+package envy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_ToTFVars(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{
+		"MYAPP_DB_HOST":   "db.example.com",
+		"MYAPP_DB_PORT":   "5432",
+		"MYAPP_RATIO":     "-0.25",
+		"MYAPP_ZIP":       "01234",
+		"MYAPP_DEBUG":     "false",
+		"MYAPP_TEMPLATE":  "${var.x} \"%{if}\"\n",
+		"MYAPP_DB_SECRET": "hunter2",
+		"OTHER":           "x",
+	})
+
+	bb := &bytes.Buffer{}
+	r.NoError(e.ToTFVars(bb, "MYAPP_", ExportOptions{Redact: []string{"*_SECRET"}}))
+	r.Equal(`db_host = "db.example.com"
+db_port = 5432
+db_secret = "***REDACTED***"
+debug = false
+ratio = -0.25
+template = "$${var.x} \"%%{if}\"\n"
+zip = "01234"
+`, bb.String())
+
+	e.Set("MYAPP_1BAD", "x")
+	r.Error(e.ToTFVars(bb, "MYAPP_", ExportOptions{}))
+}
@@ -0,0 +1,80 @@
+package envy
+
+import "time"
+
+// ttlValue is a value set with SetWithTTL, and what it replaced.
+type ttlValue struct {
+	timer  *time.Timer
+	value  string
+	prev   string
+	had    bool
+	source string
+}
+
+// SetWithTTL sets the value of key, like Set, for d: when it expires
+// key reverts to the value it had before, or becomes unset when it
+// had none, for temporary credentials and short-lived overrides in
+// long-running processes:
+//
+//	e.SetWithTTL("FEATURE_NEW_CHECKOUT", "true", 15*time.Minute)
+//
+// Setting key again with SetWithTTL replaces the expiry, still
+// reverting to the value key had before the first of them. Once the
+// value is replaced by any other means, such as Set or Load, it no
+// longer expires. The change hooks are called when it does.
+func (e *Env) SetWithTTL(key string, value string, d time.Duration) {
+	e.moot.Lock()
+	key = e.canon(key)
+	if e.ttls == nil {
+		e.ttls = map[string]*ttlValue{}
+	}
+	t, ok := e.ttls[key]
+	if ok {
+		t.timer.Stop()
+	}
+	if cur, isSet := e.get(key); !ok || cur != t.value {
+		t = &ttlValue{prev: cur, had: isSet, source: e.sources[key]}
+	}
+	t.value = value
+	e.ttls[key] = t
+	calls := e.set(key, value)
+	t.timer = time.AfterFunc(d, func() { e.expire(key, t) })
+	e.moot.Unlock()
+	runAll(calls)
+}
+
+// SetWithTTL sets the value of key in envy for d.
+func SetWithTTL(key string, value string, d time.Duration) {
+	env.SetWithTTL(key, value, d)
+}
+
+// expire reverts key, unless t was replaced since it was set.
+func (e *Env) expire(key string, t *ttlValue) {
+	e.moot.Lock()
+	if e.ttls[key] != t {
+		e.moot.Unlock()
+		return
+	}
+	delete(e.ttls, key)
+	cur, ok := e.get(key)
+	if !ok || cur != t.value {
+		e.moot.Unlock()
+		return
+	}
+	var now map[string]string
+	if t.had {
+		e.store(key, t.prev)
+		now = map[string]string{key: t.prev}
+	} else {
+		e.unseal(key)
+		delete(e.vars, key)
+	}
+	if t.source != "" {
+		e.sources[key] = t.source
+	} else {
+		delete(e.sources, key)
+	}
+	calls := e.changed(map[string]string{key: cur}, now)
+	e.moot.Unlock()
+	runAll(calls)
+}
@@ -0,0 +1,59 @@
+package envy
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_SetWithTTL(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"TOKEN": "long-lived"})
+	var mu sync.Mutex
+	var changes []Change
+	e.OnChange(func(c Change) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, c)
+	})
+
+	e.SetWithTTL("TOKEN", "short-lived", 10*time.Millisecond)
+	e.SetWithTTL("FLAG", "on", 10*time.Millisecond)
+	r.Equal("short-lived", e.Get("TOKEN", ""))
+	r.Equal("on", e.Get("FLAG", ""))
+
+	r.Eventually(func() bool {
+		_, ok := e.Lookup("FLAG")
+		return !ok && e.Get("TOKEN", "") == "long-lived"
+	}, time.Second, 5*time.Millisecond)
+	r.Equal(map[string]string{"TOKEN": "long-lived"}, e.Map())
+
+	mu.Lock()
+	r.Contains(changes, Change{Op: Changed, Key: "TOKEN", Old: "short-lived", New: "long-lived"})
+	r.Contains(changes, Change{Op: Removed, Key: "FLAG", Old: "on"})
+	mu.Unlock()
+}
+
+func Test_Env_SetWithTTL_Replaced(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"TOKEN": "a"})
+
+	// setting it again extends it, reverting to the original value
+	e.SetWithTTL("TOKEN", "b", 10*time.Millisecond)
+	e.SetWithTTL("TOKEN", "c", 200*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	r.Equal("c", e.Get("TOKEN", ""))
+	r.Eventually(func() bool {
+		return e.Get("TOKEN", "") == "a"
+	}, time.Second, 5*time.Millisecond)
+
+	// setting it by other means keeps the new value
+	e.SetWithTTL("TOKEN", "d", 10*time.Millisecond)
+	e.Set("TOKEN", "e")
+	time.Sleep(30 * time.Millisecond)
+	r.Equal("e", e.Get("TOKEN", ""))
+}
@@ -0,0 +1,224 @@
+package envy
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UnmarshalOptions control how UnmarshalWith binds structs.
+type UnmarshalOptions struct {
+	// CompatTags also binds the fields tagged for
+	// kelseyhightower/envconfig, `envconfig:"KEY" default:"x"
+	// required:"true"`, and for caarlos0/env, `env:"KEY,required"
+	// envDefault:"x"`, so their structs can be used unchanged.
+	// The envy tag takes precedence over both.
+	CompatTags bool
+}
+
+// Unmarshal sets the fields of the struct v points to from the Env,
+// see UnmarshalWith.
+func (e *Env) Unmarshal(v interface{}) error {
+	return e.UnmarshalWith(v, UnmarshalOptions{})
+}
+
+// Unmarshal sets the fields of the struct v points to from envy.
+func Unmarshal(v interface{}) error {
+	return env.Unmarshal(v)
+}
+
+// UnmarshalWith sets the fields of the struct v points to from the
+// Env. Fields are bound to keys with the envy tag, optionally followed
+// by ",required", which fails when the key isn't set, and by
+// ",notEmpty", which also fails when it's empty. The default tag holds
+// the value used when the key isn't set. Untagged struct fields are
+// bound recursively; other untagged fields are left alone.
+//
+//	type Config struct {
+//		Port    int           `envy:"PORT" default:"3000"`
+//		DB      string        `envy:"DATABASE_URL,required"`
+//		Timeout time.Duration `envy:"TIMEOUT" default:"5s"`
+//		Hosts   []string      `envy:"HOSTS"`
+//	}
+//
+// Strings, bools, numbers, durations, comma separated slices of
+// those, pointers to those, and encoding.TextUnmarshalers are
+// supported. An error is returned for every field that couldn't
+// be set, all of them joined.
+func (e *Env) UnmarshalWith(v interface{}, opts UnmarshalOptions) error {
+	return e.unmarshal(v, opts, "")
+}
+
+// unmarshal v, binding the fields to the keys prefixed with prefix.
+func (e *Env) unmarshal(v interface{}, opts UnmarshalOptions, prefix string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("can only unmarshal into a pointer to a struct, not %T", v)
+	}
+	var errs []error
+	e.unmarshalStruct(rv.Elem(), opts, prefix, &errs)
+	return joinErrors(errs...)
+}
+
+// binding of a struct field to a key.
+type binding struct {
+	key      string
+	def      string
+	hasDef   bool
+	required bool
+	notEmpty bool
+}
+
+func fieldBinding(f reflect.StructField, opts UnmarshalOptions) (binding, bool) {
+	var b binding
+	if tag, ok := f.Tag.Lookup("envy"); ok {
+		b.parse(tag)
+		b.def, b.hasDef = f.Tag.Lookup("default")
+		return b, b.key != ""
+	}
+	if !opts.CompatTags {
+		return b, false
+	}
+	if tag, ok := f.Tag.Lookup("env"); ok {
+		b.parse(tag)
+		b.def, b.hasDef = f.Tag.Lookup("envDefault")
+		return b, b.key != ""
+	}
+	if tag, ok := f.Tag.Lookup("envconfig"); ok && tag != "-" {
+		b.key = strings.ToUpper(strings.Split(tag, ",")[0])
+		b.def, b.hasDef = f.Tag.Lookup("default")
+		b.required, _ = strconv.ParseBool(f.Tag.Get("required"))
+		return b, b.key != ""
+	}
+	return b, false
+}
+
+func (b *binding) parse(tag string) {
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" {
+		return
+	}
+	b.key = parts[0]
+	for _, p := range parts[1:] {
+		switch p {
+		case "required":
+			b.required = true
+		case "notEmpty":
+			b.required = true
+			b.notEmpty = true
+		}
+	}
+}
+
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func (e *Env) unmarshalStruct(rv reflect.Value, opts UnmarshalOptions, prefix string, errs *[]error) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+
+		b, ok := fieldBinding(f, opts)
+		if !ok {
+			if _, tagged := f.Tag.Lookup("envy"); !tagged && fv.Kind() == reflect.Struct && !reflect.PtrTo(f.Type).Implements(textUnmarshaler) {
+				e.unmarshalStruct(fv, opts, prefix, errs)
+			}
+			continue
+		}
+
+		b.key = prefix + b.key
+		v, found := e.Lookup(b.key)
+		if !found && b.hasDef {
+			v, found = b.def, true
+		}
+		switch {
+		case !found && b.required:
+			*errs = append(*errs, fmt.Errorf("%s: required but not set", b.key))
+			continue
+		case !found:
+			continue
+		case v == "" && b.notEmpty:
+			*errs = append(*errs, fmt.Errorf("%s: must not be empty", b.key))
+			continue
+		}
+		if err := setField(fv, v); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", b.key, err))
+		}
+	}
+}
+
+func setField(fv reflect.Value, v string) error {
+	if fv.CanAddr() && fv.Addr().Type().Implements(textUnmarshaler) {
+		return fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v))
+	}
+
+	switch fv.Kind() {
+	case reflect.Ptr:
+		p := reflect.New(fv.Type().Elem())
+		if err := setField(p.Elem(), v); err != nil {
+			return err
+		}
+		fv.Set(p)
+		return nil
+	case reflect.Slice:
+		if v == "" {
+			fv.Set(reflect.MakeSlice(fv.Type(), 0, 0))
+			return nil
+		}
+		parts := strings.Split(v, ",")
+		s := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setField(s.Index(i), strings.TrimSpace(p)); err != nil {
+				return err
+			}
+		}
+		fv.Set(s)
+		return nil
+	case reflect.String:
+		fv.SetString(v)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("not a valid bool: %q", v)
+		}
+		fv.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("not a valid duration: %q", v)
+			}
+			fv.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(v, 0, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("not a valid %s: %q", fv.Type(), v)
+		}
+		fv.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(v, 0, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("not a valid %s: %q", fv.Type(), v)
+		}
+		fv.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(v, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("not a valid %s: %q", fv.Type(), v)
+		}
+		fv.SetFloat(n)
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", fv.Type())
+}
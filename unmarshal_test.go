@@ -0,0 +1,110 @@
+package envy
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Unmarshal(t *testing.T) {
+	r := require.New(t)
+
+	type DB struct {
+		URL  string `envy:"DATABASE_URL,required"`
+		Pool *int   `envy:"DB_POOL"`
+	}
+	type Config struct {
+		Port    int           `envy:"PORT" default:"3000"`
+		Debug   bool          `envy:"DEBUG"`
+		Timeout time.Duration `envy:"TIMEOUT" default:"5s"`
+		Hosts   []string      `envy:"HOSTS"`
+		Ratio   float64       `envy:"RATIO"`
+		IP      net.IP        `envy:"IP"`
+		Skipped string        `envy:"-"`
+		Ignored string
+		DB      DB
+	}
+
+	e := FromMap(map[string]string{
+		"DEBUG":        "true",
+		"HOSTS":        "a, b",
+		"RATIO":        "0.5",
+		"IP":           "127.0.0.1",
+		"DATABASE_URL": "postgres://",
+		"DB_POOL":      "5",
+		"Ignored":      "x",
+	})
+
+	c := Config{}
+	r.NoError(e.Unmarshal(&c))
+	r.Equal(3000, c.Port)
+	r.True(c.Debug)
+	r.Equal(5*time.Second, c.Timeout)
+	r.Equal([]string{"a", "b"}, c.Hosts)
+	r.Equal(0.5, c.Ratio)
+	r.Equal("127.0.0.1", c.IP.String())
+	r.Empty(c.Ignored)
+	r.Equal("postgres://", c.DB.URL)
+	r.Equal(5, *c.DB.Pool)
+}
+
+func Test_Env_Unmarshal_Errors(t *testing.T) {
+	r := require.New(t)
+
+	type Config struct {
+		Port  int    `envy:"PORT"`
+		URL   string `envy:"DATABASE_URL,required"`
+		Name  string `envy:"NAME,notEmpty"`
+		Debug bool   `envy:"DEBUG"`
+	}
+
+	e := FromMap(map[string]string{"PORT": "nope", "NAME": "", "DEBUG": "yes"})
+	err := e.Unmarshal(&Config{})
+	r.EqualError(err, `PORT: not a valid int: "nope"
+DATABASE_URL: required but not set
+NAME: must not be empty
+DEBUG: not a valid bool: "yes"`)
+
+	r.Error(e.Unmarshal(Config{}))
+	r.Error(e.Unmarshal(nil))
+}
+
+func Test_Env_UnmarshalWith_CompatTags(t *testing.T) {
+	r := require.New(t)
+
+	type Config struct {
+		Port  int    `envconfig:"port" default:"3000"`
+		URL   string `envconfig:"DATABASE_URL" required:"true"`
+		Host  string `env:"HOST,required"`
+		Mode  string `env:"MODE" envDefault:"dev"`
+		Envy  string `envy:"ENVY" env:"NOT_ENVY"`
+		Other string `env:"-"`
+	}
+
+	e := FromMap(map[string]string{
+		"PORT":         "4000",
+		"DATABASE_URL": "postgres://",
+		"HOST":         "localhost",
+		"ENVY":         "envy",
+		"NOT_ENVY":     "not envy",
+	})
+
+	c := Config{}
+	r.NoError(e.Unmarshal(&c))
+	r.Equal(Config{Envy: "envy"}, c)
+
+	r.NoError(e.UnmarshalWith(&c, UnmarshalOptions{CompatTags: true}))
+	r.Equal(Config{
+		Port: 4000,
+		URL:  "postgres://",
+		Host: "localhost",
+		Mode: "dev",
+		Envy: "envy",
+	}, c)
+
+	e.Unset("HOST")
+	err := e.UnmarshalWith(&Config{}, UnmarshalOptions{CompatTags: true})
+	r.EqualError(err, "HOST: required but not set")
+}
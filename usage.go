@@ -0,0 +1,88 @@
+package envy
+
+import "sort"
+
+// readCount counts how many times a key was read, and not found.
+type readCount struct {
+	hits   int
+	misses int
+}
+
+// track counts a read of key.
+func (e *Env) track(key string, found bool) {
+	e.usageMoot.Lock()
+	defer e.usageMoot.Unlock()
+	r := e.reads[key]
+	if found {
+		r.hits++
+	} else {
+		r.misses++
+	}
+	e.reads[key] = r
+}
+
+// UnusedKeys returns the sorted keys that were supplied to the Env,
+// by Load, Set, or MustSet, and were never read through Get, MustGet,
+// or Lookup. They're often typos, or configuration nothing uses
+// anymore. The keys inherited from the OS aren't reported.
+func (e *Env) UnusedKeys() []string {
+	e.moot.RLock()
+	var keys []string
+	for k := range e.sources {
+		if _, ok := e.get(k); ok {
+			keys = append(keys, k)
+		}
+	}
+	e.moot.RUnlock()
+
+	e.usageMoot.Lock()
+	defer e.usageMoot.Unlock()
+	unused := keys[:0]
+	for _, k := range keys {
+		if e.reads[k].hits == 0 {
+			unused = append(unused, k)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// UnusedKeys returns the sorted keys that were supplied
+// to envy, and were never read.
+func UnusedKeys() []string {
+	return env.UnusedKeys()
+}
+
+// AccessedKeys returns the sorted keys that were read through Get,
+// MustGet, or Lookup, since the Env was created or ResetAccessed was
+// last called, whether or not they were found. Unlike a Recorder,
+// it's always on, so it can report the configuration a service
+// actually used when it shuts down.
+func (e *Env) AccessedKeys() []string {
+	e.usageMoot.Lock()
+	defer e.usageMoot.Unlock()
+	keys := make([]string, 0, len(e.reads))
+	for k := range e.reads {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// AccessedKeys returns the sorted keys that were read through envy.
+func AccessedKeys() []string {
+	return env.AccessedKeys()
+}
+
+// ResetAccessed forgets about the keys read so far, for both
+// AccessedKeys and UnusedKeys.
+func (e *Env) ResetAccessed() {
+	e.usageMoot.Lock()
+	defer e.usageMoot.Unlock()
+	e.reads = map[string]readCount{}
+}
+
+// ResetAccessed forgets about the keys read through envy so far.
+func ResetAccessed() {
+	env.ResetAccessed()
+}
@@ -0,0 +1,43 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_UnusedKeys(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"FROM_MAP": "x"})
+	r.NoError(e.Load("test_env/.env"))
+	e.Set("PORT", "3000")
+	e.Set("HOST", "localhost")
+
+	e.Get("DIR", "")
+	e.Get("PORT", "")
+	e.Get("MISSING", "")
+	r.Equal([]string{"FLAVOUR", "HOST", "INSIDE_FOLDER"}, e.UnusedKeys())
+
+	e.Unset("HOST")
+	e.MustGet("FLAVOUR")
+	r.Equal([]string{"INSIDE_FOLDER"}, e.UnusedKeys())
+}
+
+func Test_Env_AccessedKeys(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"PORT": "3000"})
+	r.Empty(e.AccessedKeys())
+
+	e.Get("PORT", "")
+	e.Get("PORT", "")
+	e.Lookup("HOST")
+	e.Map()
+	r.Equal([]string{"HOST", "PORT"}, e.AccessedKeys())
+
+	e.ResetAccessed()
+	r.Empty(e.AccessedKeys())
+	e.Get("PORT", "")
+	r.Equal([]string{"PORT"}, e.AccessedKeys())
+}
@@ -0,0 +1,53 @@
+package envy
+
+import "fmt"
+
+// ValueSource looks a single key up in an Env. It implements the
+// ValueSource, and EnvValueSource, interfaces of urfave/cli, so the
+// flags of an app built with it get their values from envy, with
+// its .env files, rather than straight from the OS:
+//
+//	&cli.IntFlag{
+//		Name:    "port",
+//		Sources: cli.NewValueSourceChain(envy.Source("PORT")),
+//	}
+type ValueSource struct {
+	env *Env
+	key string
+}
+
+// ValueSource returns a ValueSource looking key up in the Env.
+func (e *Env) ValueSource(key string) *ValueSource {
+	return &ValueSource{env: e, key: key}
+}
+
+// Source returns a ValueSource looking key up in envy.
+func Source(key string) *ValueSource {
+	return env.ValueSource(key)
+}
+
+// Lookup the value of the key, reporting whether it was found.
+func (s *ValueSource) Lookup() (string, bool) {
+	return s.env.Lookup(s.key)
+}
+
+// Key returns the key looked up.
+func (s *ValueSource) Key() string {
+	return s.key
+}
+
+// IsFromEnv reports that the value comes from the ENV,
+// so urfave/cli shows the key in its help.
+func (s *ValueSource) IsFromEnv() bool {
+	return true
+}
+
+// String implements fmt.Stringer.
+func (s *ValueSource) String() string {
+	return fmt.Sprintf("envy key %q", s.key)
+}
+
+// GoString implements fmt.GoStringer.
+func (s *ValueSource) GoString() string {
+	return fmt.Sprintf("&envy.ValueSource{Key:%q}", s.key)
+}
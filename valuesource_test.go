@@ -0,0 +1,38 @@
+package envy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cliValueSource is the ValueSource interface of urfave/cli.
+type cliValueSource interface {
+	fmt.Stringer
+	fmt.GoStringer
+	Lookup() (string, bool)
+}
+
+func Test_Env_ValueSource(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"PORT": "3000"})
+	var s cliValueSource = e.ValueSource("PORT")
+
+	v, ok := s.Lookup()
+	r.True(ok)
+	r.Equal("3000", v)
+	r.Equal(`envy key "PORT"`, s.String())
+	r.Equal(`&envy.ValueSource{Key:"PORT"}`, s.GoString())
+
+	_, ok = e.ValueSource("HOST").Lookup()
+	r.False(ok)
+
+	e.Set("HOST", "localhost")
+	v, ok = e.ValueSource("HOST").Lookup()
+	r.True(ok)
+	r.Equal("localhost", v)
+	r.True(e.ValueSource("HOST").IsFromEnv())
+	r.Equal("HOST", e.ValueSource("HOST").Key())
+}
@@ -0,0 +1,39 @@
+package envy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Vendored reports whether the go tool builds the current module, see
+// CurrentModule, with its vendored dependencies, following its rules:
+// a -mod flag in GOFLAGS decides, and otherwise the vendor directory
+// is used when it exists and the go.mod requires Go 1.14 or later.
+func (e *Env) Vendored() bool {
+	for _, f := range e.GoFlags() {
+		if goFlagName(f) == "mod" {
+			return strings.HasSuffix(f, "=vendor")
+		}
+	}
+
+	m, err := currentModule()
+	if err != nil {
+		return false
+	}
+	if fi, err := os.Stat(filepath.Join(m.Dir, "vendor")); err != nil || !fi.IsDir() {
+		return false
+	}
+	v := modDirective(m.gomod, "go")
+	if v == "" {
+		return false
+	}
+	c, err := CompareGoVersions(v, "1.14")
+	return err == nil && c >= 0
+}
+
+// Vendored reports whether the go tool builds
+// the current module with its vendored dependencies.
+func Vendored() bool {
+	return env.Vendored()
+}
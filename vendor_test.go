@@ -0,0 +1,41 @@
+package envy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_Vendored(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	r.NoError(ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module app\n\ngo 1.13\n"), 0644))
+	r.NoError(os.MkdirAll(filepath.Join(dir, "vendor"), 0755))
+	r.NoError(os.MkdirAll(filepath.Join(dir, "pkg"), 0755))
+
+	pwd, err := os.Getwd()
+	r.NoError(err)
+	r.NoError(os.Chdir(filepath.Join(dir, "pkg")))
+	defer os.Chdir(pwd)
+
+	e := FromMap(map[string]string{})
+	r.False(e.Vendored())
+
+	e.Set("GOFLAGS", "-mod=vendor")
+	r.True(e.Vendored())
+
+	r.NoError(ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module app\n\ngo 1.21\n"), 0644))
+	e.Set("GOFLAGS", "-v")
+	r.True(e.Vendored())
+
+	e.Set("GOFLAGS", "-mod=mod")
+	r.False(e.Vendored())
+
+	e.Set("GOFLAGS", "")
+	r.NoError(os.Remove(filepath.Join(dir, "vendor")))
+	r.False(e.Vendored())
+}
@@ -0,0 +1,81 @@
+package envy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Settings is implemented by the configuration libraries holding
+// their settings as nested maps, such as *viper.Viper.
+type Settings interface {
+	AllSettings() map[string]interface{}
+}
+
+// ConfigReader returns the exported variables as a JSON document,
+// for the configuration libraries reading their settings from one,
+// such as viper:
+//
+//	r, err := envy.Default().ConfigReader(envy.ExportOptions{Keys: []string{"MYAPP_*"}})
+//	if err != nil {
+//		return err
+//	}
+//	v.SetConfigType("json")
+//	err = v.MergeConfig(r)
+func (e *Env) ConfigReader(opts ExportOptions) (io.Reader, error) {
+	bb := &bytes.Buffer{}
+	if err := e.ToJSON(bb, opts); err != nil {
+		return nil, err
+	}
+	return bb, nil
+}
+
+// LoadSettings sets the settings of s, such as a *viper.Viper, in
+// the Env, like Set. The keys of nested settings are joined with
+// underscores, uppercased, and sanitized, see SanitizeKey, so
+// db.host becomes DB_HOST, and log-level LOG_LEVEL. Lists
+// are joined with commas, and other values formatted with fmt.Sprint.
+// Unlike the values set with Set, they're kept when an Env bound to
+// the OS is reloaded, see Reload.
+func (e *Env) LoadSettings(s Settings) {
+	m := flattenSettings(s.AllSettings())
+	e.moot.Lock()
+	var calls []func()
+	for k, v := range m {
+		calls = append(calls, e.set(k, v)...)
+		e.sources[k] = "settings"
+		e.settingVars[k] = v
+	}
+	e.moot.Unlock()
+	runAll(calls)
+}
+
+// flattenSettings flattens nested settings into ENV variables.
+func flattenSettings(settings map[string]interface{}) map[string]string {
+	m := map[string]string{}
+	var walk func(prefix string, s map[string]interface{})
+	walk = func(prefix string, s map[string]interface{}) {
+		for k, v := range s {
+			k = SanitizeKey(strings.ToUpper(prefix + k))
+			switch v := v.(type) {
+			case map[string]interface{}:
+				walk(k+"_", v)
+			case []interface{}:
+				parts := make([]string, len(v))
+				for i, p := range v {
+					parts[i] = fmt.Sprint(p)
+				}
+				m[k] = strings.Join(parts, ",")
+			case []string:
+				m[k] = strings.Join(v, ",")
+			case nil:
+				m[k] = ""
+			default:
+				m[k] = fmt.Sprint(v)
+			}
+		}
+	}
+	walk("", settings)
+	return m
+}
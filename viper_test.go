@@ -0,0 +1,123 @@
+package envy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type settings map[string]interface{}
+
+func (s settings) AllSettings() map[string]interface{} {
+	return s
+}
+
+func Test_Env_ConfigReader(t *testing.T) {
+	r := require.New(t)
+
+	e := FromMap(map[string]string{"MYAPP_PORT": "3000", "HOME": "/root"})
+	rd, err := e.ConfigReader(ExportOptions{Keys: []string{"MYAPP_*"}})
+	r.NoError(err)
+	b, err := ioutil.ReadAll(rd)
+	r.NoError(err)
+
+	m := map[string]string{}
+	r.NoError(json.Unmarshal(b, &m))
+	r.Equal(map[string]string{"MYAPP_PORT": "3000"}, m)
+}
+
+func Test_Env_LoadSettings(t *testing.T) {
+	r := require.New(t)
+
+	s := settings{
+		"port": 3000,
+		"db": map[string]interface{}{
+			"host":      "localhost",
+			"pool-size": 5,
+		},
+		"hosts": []interface{}{"a", "b"},
+		"debug": true,
+		"empty": nil,
+		"2fa":   "on",
+	}
+
+	want := map[string]string{
+		"PORT":         "3000",
+		"DB_HOST":      "localhost",
+		"DB_POOL_SIZE": "5",
+		"HOSTS":        "a,b",
+		"DEBUG":        "true",
+		"EMPTY":        "",
+		"_2FA":         "on",
+	}
+
+	e := FromMap(map[string]string{})
+	e.LoadSettings(s)
+	r.Equal(want, e.Map())
+	r.Contains(e.UnusedKeys(), "DB_HOST")
+
+	e = New(WithOSEnv(false), WithSettings(s))
+	r.Equal(want, e.Map())
+}
+
+func Test_WithSettings(t *testing.T) {
+	r := require.New(t)
+	t.Setenv("ENVY_SETTINGS_PORT", "4000")
+
+	s := settings{
+		"envy_settings_port": 3000,
+		"db_host":            "localhost",
+		"api_token":          "hunter2",
+	}
+
+	// applied after WithWipedSecrets, whatever the order
+	e := New(WithSettings(s), WithWipedSecrets(true))
+	r.Equal("4000", e.Get("ENVY_SETTINGS_PORT", ""))
+	r.Equal("localhost", e.Get("DB_HOST", ""))
+	r.Equal("hunter2", e.Get("API_TOKEN", ""))
+	_, ok := e.vars["API_TOKEN"]
+	r.False(ok)
+	r.Contains(e.sealed, "API_TOKEN")
+
+	// the settings are only the source of the values they supplied
+	r.Equal("settings", e.sources["DB_HOST"])
+	r.NotEqual("settings", e.sources["ENVY_SETTINGS_PORT"])
+
+	e = New(WithOSEnv(false), WithSettings(s), WithWipedSecrets(true))
+	r.Equal("3000", e.Get("ENVY_SETTINGS_PORT", ""))
+	r.Equal("settings", e.sources["ENVY_SETTINGS_PORT"])
+	r.Contains(e.sealed, "API_TOKEN")
+}
+
+func Test_WithSettings_Reload(t *testing.T) {
+	r := require.New(t)
+	t.Setenv("ENVY_SETTINGS_LOADED", "")
+	os.Unsetenv("ENVY_SETTINGS_LOADED")
+
+	path := filepath.Join(t.TempDir(), "a.env")
+	r.NoError(ioutil.WriteFile(path, []byte("ENVY_SETTINGS_LOADED=file\n"), 0o600))
+
+	e := New(WithSettings(settings{
+		"envy_settings_foo":    "bar",
+		"envy_settings_loaded": "settings",
+	}))
+	r.Equal("bar", e.Get("ENVY_SETTINGS_FOO", ""))
+	r.Equal("settings", e.Get("ENVY_SETTINGS_LOADED", ""))
+
+	// loading a file reloads the Env from the OS
+	r.NoError(e.Load(path))
+	r.Equal("bar", e.Get("ENVY_SETTINGS_FOO", ""))
+	r.Equal("settings", e.sources["ENVY_SETTINGS_FOO"])
+	r.Equal("file", e.Get("ENVY_SETTINGS_LOADED", ""))
+	r.Equal(path, e.sources["ENVY_SETTINGS_LOADED"])
+
+	e.LoadSettings(settings{"envy_settings_baz": "qux"})
+	e.Reload()
+	r.Equal("bar", e.Get("ENVY_SETTINGS_FOO", ""))
+	r.Equal("qux", e.Get("ENVY_SETTINGS_BAZ", ""))
+	r.Equal("file", e.Get("ENVY_SETTINGS_LOADED", ""))
+}
@@ -0,0 +1,130 @@
+package envy
+
+// Unset removes key from the Env, zeroing its value when it was
+// kept in a wiped buffer. Like Set, it doesn't affect the OS.
+func (e *Env) Unset(key string) {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	key = e.canon(key)
+	e.unseal(key)
+	delete(e.vars, key)
+	delete(e.sources, key)
+	e.invalidateDerived()
+}
+
+// Unset removes key from envy. It doesn't affect the OS.
+func Unset(key string) {
+	env.Unset(key)
+}
+
+// Clear removes all of the keys from the Env, zeroing
+// the values kept in wiped buffers.
+func (e *Env) Clear() {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	wipeAll(e.sealed)
+	e.sealed = map[string][]byte{}
+	e.vars = map[string]string{}
+	e.sources = map[string]string{}
+	e.invalidateDerived()
+}
+
+// Wipe zeroes, and removes from the Env, the secrets kept in
+// wiped buffers, see WithWipedSecrets. Go has no exit hooks, so
+// programs should defer it in main, and call it from their
+// signal handlers, to limit how long the secrets stay in memory.
+func (e *Env) Wipe() {
+	e.moot.Lock()
+	defer e.moot.Unlock()
+	wipeAll(e.sealed)
+	e.sealed = map[string][]byte{}
+	e.invalidateDerived()
+}
+
+// Wipe zeroes, and removes from envy, the
+// secrets kept in wiped buffers.
+func Wipe() {
+	env.Wipe()
+}
+
+// get returns the value of key, wherever it's kept.
+// It must be called with e.moot held.
+func (e *Env) get(key string) (string, bool) {
+	key = e.canon(key)
+	if v, ok := e.vars[key]; ok {
+		return v, true
+	}
+	if b, ok := e.sealed[key]; ok {
+		return e.openValue(b), true
+	}
+	return "", false
+}
+
+// all returns a copy of all of the variables, including
+// the secrets. It must be called with e.moot held.
+func (e *Env) all() map[string]string {
+	m := copyVars(e.vars)
+	for k, b := range e.sealed {
+		m[k] = e.openValue(b)
+	}
+	return m
+}
+
+// store the value of key, in a wiped buffer when it's a secret and
+// the Env wipes, or encrypts, them. It must be called with e.moot held.
+func (e *Env) store(key string, value string) {
+	e.unseal(key)
+	if e.sealing() && e.isSecret(key) {
+		delete(e.vars, key)
+		e.sealed[key] = e.sealValue(value)
+		return
+	}
+	e.vars[key] = value
+}
+
+// seal moves the secrets kept as strings into wiped buffers.
+// It must be called with e.moot held.
+func (e *Env) seal() {
+	if !e.sealing() {
+		return
+	}
+	for k, v := range e.vars {
+		if e.isSecret(k) {
+			delete(e.vars, k)
+			e.sealed[k] = e.sealValue(v)
+		}
+	}
+}
+
+func (e *Env) sealing() bool {
+	return e.wipe || e.encrypt
+}
+
+// unseal zeroes, and removes, the wiped buffer of key.
+// It must be called with e.moot held.
+func (e *Env) unseal(key string) {
+	if b, ok := e.sealed[key]; ok {
+		wipe(b)
+		delete(e.sealed, key)
+	}
+}
+
+func copySealed(m map[string][]byte) map[string][]byte {
+	cp := make(map[string][]byte, len(m))
+	for k, b := range m {
+		cp[k] = append([]byte(nil), b...)
+	}
+	return cp
+}
+
+func wipeAll(m map[string][]byte) {
+	for _, b := range m {
+		wipe(b)
+	}
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,59 @@
+package envy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Env_WithWipedSecrets(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithWipedSecrets(true))
+	e.Set("API_TOKEN", "abc")
+	e.Set("PORT", "3000")
+
+	r.Equal("abc", e.Get("API_TOKEN", ""))
+	r.Equal(map[string]string{"API_TOKEN": "abc", "PORT": "3000"}, e.Map())
+	r.NotContains(e.vars, "API_TOKEN")
+
+	b := e.sealed["API_TOKEN"]
+	e.Set("API_TOKEN", "xyz")
+	r.Equal([]byte{0, 0, 0}, b)
+	r.Equal("xyz", e.Get("API_TOKEN", ""))
+
+	b = e.sealed["API_TOKEN"]
+	e.Unset("API_TOKEN")
+	r.Equal([]byte{0, 0, 0}, b)
+	_, ok := e.Lookup("API_TOKEN")
+	r.False(ok)
+
+	e.Set("PORT", "4000")
+	e.MarkSecret("PORT")
+	r.NotContains(e.vars, "PORT")
+	b = e.sealed["PORT"]
+	e.Wipe()
+	r.Equal([]byte{0, 0, 0, 0}, b)
+	r.Empty(e.Map())
+}
+
+func Test_Env_WithWipedSecrets_Temp(t *testing.T) {
+	r := require.New(t)
+
+	e := New(WithOSEnv(false), WithWipedSecrets(true))
+	e.Set("API_TOKEN", "abc")
+
+	var b []byte
+	e.Temp(func() {
+		e.Set("API_TOKEN", "tmp")
+		b = e.sealed["API_TOKEN"]
+	})
+	r.Equal([]byte{0, 0, 0}, b)
+	r.Equal("abc", e.Get("API_TOKEN", ""))
+
+	c := e.Clone()
+	r.Equal("abc", c.Get("API_TOKEN", ""))
+	c.Clear()
+	r.Empty(c.Map())
+	r.Equal("abc", e.Get("API_TOKEN", ""))
+}
@@ -0,0 +1,133 @@
+package envy
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// UpdateFile sets the given variables in the .env file at path,
+// preserving its comments, blank lines, and the order of its keys.
+// Lines defining keys that are already in the file are rewritten
+// in place; the other keys are appended, sorted, at the end of the
+// file. The file is created if it doesn't exist.
+func UpdateFile(path string, vars map[string]string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	mode := os.FileMode(0644)
+	if fi, err := os.Stat(path); err == nil {
+		mode = fi.Mode()
+	}
+
+	seen := map[string]bool{}
+	var lines []string
+	if len(b) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	}
+	for i, line := range lines {
+		k, _, err := ParseLine(line, nil)
+		if err != nil || k == "" {
+			continue
+		}
+		v, ok := vars[k]
+		if !ok {
+			continue
+		}
+		prefix := ""
+		if strings.HasPrefix(strings.TrimSpace(line), "export") {
+			prefix = "export "
+		}
+		lines[i] = prefix + k + "=" + formatValue(v)
+		seen[k] = true
+	}
+
+	var keys []string
+	for k := range vars {
+		if !seen[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, k+"="+formatValue(vars[k]))
+	}
+
+	bb := &bytes.Buffer{}
+	for _, line := range lines {
+		bb.WriteString(line)
+		bb.WriteByte('\n')
+	}
+	return writeFile(path, bb.Bytes(), mode)
+}
+
+// writeFile writes b to the file at path with the given mode.
+func writeFile(path string, b []byte, mode os.FileMode) error {
+	// write to a temporary file first, so the .env file is never
+	// left half written
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// FormatDotenv renders m in the .env format, sorted by key. Values
+// are quoted and escaped as needed, so parsing the result with
+// ParseLine, or loading it, returns exactly the same values.
+func FormatDotenv(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	bb := &strings.Builder{}
+	for _, k := range keys {
+		bb.WriteString(k)
+		bb.WriteByte('=')
+		bb.WriteString(formatValue(m[k]))
+		bb.WriteByte('\n')
+	}
+	return bb.String()
+}
+
+// String returns the Env in the .env format, see FormatDotenv,
+// with the values of the secrets redacted, so it's safe to log.
+func (e *Env) String() string {
+	return FormatDotenv(e.RedactedMap())
+}
+
+// valueQuoter escapes the values in double quotes. Tabs are kept as
+// is, as godotenv, the default Loader, doesn't unescape \t.
+var valueQuoter = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, `$`, `\$`)
+
+// formatValue returns v as it should be written in a .env file,
+// quoting it only when it contains characters that need it.
+func formatValue(v string) string {
+	for _, c := range v {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.ContainsRune("_-.,/:@+%=", c):
+		default:
+			return `"` + valueQuoter.Replace(v) + `"`
+		}
+	}
+	return v
+}
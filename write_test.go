@@ -0,0 +1,129 @@
+package envy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdateFile(t *testing.T) {
+	r := require.New(t)
+
+	path := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(path, []byte("# database\nexport DB_HOST=localhost # local\n\nDB_PORT: 5432\n"), 0600))
+
+	err := UpdateFile(path, map[string]string{
+		"DB_HOST": "db.example.com",
+		"Z_NEW":   "has spaces # and \"quotes\"",
+		"A_NEW":   "$literal",
+	})
+	r.NoError(err)
+
+	b, err := ioutil.ReadFile(path)
+	r.NoError(err)
+	r.Equal(`# database
+export DB_HOST=db.example.com
+
+DB_PORT: 5432
+A_NEW="\$literal"
+Z_NEW="has spaces # and \"quotes\""
+`, string(b))
+
+	e := New(WithOSEnv(false))
+	r.NoError(e.Load(path))
+	r.Equal("db.example.com", e.Get("DB_HOST", ""))
+	r.Equal("5432", e.Get("DB_PORT", ""))
+
+	r.NoError(UpdateFile(filepath.Join(t.TempDir(), "new.env"), map[string]string{"A": "1"}))
+}
+
+func Test_formatValue(t *testing.T) {
+	vars := map[string]string{"X": "x"}
+	table := []string{"", "plain", "a b", "$X", "it's", "a\nb\t\"c\"\\", "#hash", "url=postgres://u:p@h/db?x=1"}
+
+	for _, v := range table {
+		t.Run(v, func(st *testing.T) {
+			r := require.New(st)
+			_, got, err := ParseLine("KEY="+formatValue(v), vars)
+			r.NoError(err)
+			r.Equal(v, got)
+		})
+	}
+}
+
+func Test_FormatDotenv(t *testing.T) {
+	r := require.New(t)
+
+	m := map[string]string{
+		"B":      "plain",
+		"A":      " padded ",
+		"HASH":   "a #b",
+		"QUOTES": `it's "quoted"`,
+		"MULTI":  "a\nb\r\n",
+		"EMPTY":  "",
+	}
+	s := FormatDotenv(m)
+	r.Equal(`A=" padded "
+B=plain
+EMPTY=
+HASH="a #b"
+MULTI="a\nb\r\n"
+QUOTES="it's \"quoted\""
+`, s)
+
+	got := map[string]string{}
+	for _, line := range strings.Split(s, "\n") {
+		k, v, err := ParseLine(line, nil)
+		r.NoError(err)
+		if k != "" {
+			got[k] = v
+		}
+	}
+	r.Equal(m, got)
+
+	r.Equal("A=a\n", FromMap(map[string]string{"A": "a"}).String())
+}
+
+func Test_FormatDotenv_Load(t *testing.T) {
+	r := require.New(t)
+
+	m := map[string]string{
+		"TAB":     "tab\there",
+		"EDGES":   "\tpadded ",
+		"CRLF":    "a\r\nb",
+		"ESCAPES": `back\slash \n "dq" 'sq'`,
+		"VARS":    "$HOME ${HOME}",
+		"HASH":    "a #b",
+		"SHELL":   "!bang `tick`",
+		"EQUALS":  "a=b",
+	}
+	path := filepath.Join(t.TempDir(), ".env")
+	r.NoError(ioutil.WriteFile(path, []byte(FormatDotenv(m)), 0644))
+
+	// with the default loader
+	e := FromMap(nil)
+	r.NoError(e.Load(path))
+	r.Equal(m, e.Map())
+
+	e = New(WithOSEnv(false), WithLoader(NativeLoader{}))
+	r.NoError(e.Load(path))
+	r.Equal(m, e.Map())
+}
+
+func FuzzFormatDotenv(f *testing.F) {
+	f.Add("plain")
+	f.Add(" #$HOME 'x' \"y\"\n\\")
+	f.Fuzz(func(t *testing.T, v string) {
+		line := strings.TrimSuffix(FormatDotenv(map[string]string{"KEY": v}), "\n")
+		_, got, err := ParseLine(line, map[string]string{"HOME": "/home"})
+		if err != nil {
+			t.Fatalf("%q could not be parsed: %s", line, err)
+		}
+		if got != v {
+			t.Fatalf("%q round tripped as %q through %q", v, got, line)
+		}
+	})
+}